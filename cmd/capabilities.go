@@ -0,0 +1,266 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/loaders"
+	"claudectl/internal/viewmodels"
+)
+
+// CapabilityLoaders bundles the loaders for commands that work on any
+// capability type
+type CapabilityLoaders struct {
+	fx.In
+
+	MCP      loaders.MCPLoader
+	Commands loaders.Loader[domain.Command]
+	Skills   loaders.Loader[domain.Skill]
+	Agents   loaders.Loader[domain.Agent]
+	Plugins  loaders.Loader[domain.Plugin]
+}
+
+var capabilityTypes = []domain.CapabilityType{
+	domain.TypeMCP, domain.TypeCommand, domain.TypeSkill, domain.TypeAgent, domain.TypePlugin,
+}
+
+// parseCapabilityType validates a capability type given by the user, in
+// the singular or plural
+func parseCapabilityType(s string) (domain.CapabilityType, error) {
+	for _, kind := range capabilityTypes {
+		if string(kind) == s || string(kind)+"s" == s {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("unknown type %q (expected mcp, command, skill, agent or plugin)", s)
+}
+
+// Load returns the capabilities of one type. An empty scope loads user and
+// project, which includes local MCP servers.
+func (l CapabilityLoaders) Load(kind domain.CapabilityType, scope domain.CapabilityScope) ([]viewmodels.CapabilityViewModel, error) {
+	_, result, err := l.load(kind, scope)
+	return result, err
+}
+
+// LoadDomain is Load returning the domain values behind the view models
+func (l CapabilityLoaders) LoadDomain(kind domain.CapabilityType, scope domain.CapabilityScope) ([]any, error) {
+	items, _, err := l.load(kind, scope)
+	return items, err
+}
+
+// load returns the domain values of one type alongside their view models
+func (l CapabilityLoaders) load(kind domain.CapabilityType, scope domain.CapabilityScope) ([]any, []viewmodels.CapabilityViewModel, error) {
+	scopes := []domain.CapabilityScope{domain.ScopeUser, domain.ScopeProject}
+	if scope != "" {
+		scopes = []domain.CapabilityScope{scope}
+		if scope == domain.ScopeLocal {
+			scopes = []domain.CapabilityScope{domain.ScopeProject}
+		}
+	}
+
+	// Agents' mcp__ tools are checked against every configured server. A
+	// broken MCP config leaves servers nil, which skips the check rather
+	// than failing to list agents.
+	var servers []string
+	if kind == domain.TypeAgent {
+		servers, _ = l.MCPServerNames()
+	}
+
+	var values []any
+	var result []viewmodels.CapabilityViewModel
+	for _, s := range scopes {
+		var items []any
+		var err error
+		switch kind {
+		case domain.TypeMCP:
+			items, err = loadAny[domain.MCPServer](l.MCP, s)
+		case domain.TypeCommand:
+			items, err = loadAny(l.Commands, s)
+		case domain.TypeSkill:
+			items, err = loadAny(l.Skills, s)
+		case domain.TypeAgent:
+			items, err = loadAny(l.Agents, s)
+		case domain.TypePlugin:
+			items, err = loadAny(l.Plugins, s)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("loading %s %ss: %w", s, kind, err)
+		}
+		for _, item := range items {
+			vm, err := viewmodels.ToDomainViewModel(item)
+			if err != nil {
+				return nil, nil, err
+			}
+			if agent, ok := vm.(*viewmodels.AgentViewModel); ok {
+				agent.ResolveTools(servers)
+			}
+			if scope == "" || vm.GetScope() == scope {
+				values = append(values, item)
+				result = append(result, vm)
+			}
+		}
+	}
+	return values, result, nil
+}
+
+// FindPlugins returns the installed plugins with the given names
+func (l CapabilityLoaders) FindPlugins(names []string, scope domain.CapabilityScope) ([]domain.Plugin, error) {
+	values, items, err := l.load(domain.TypePlugin, scope)
+	if err != nil {
+		return nil, err
+	}
+	var plugins []domain.Plugin
+	for _, name := range names {
+		found := false
+		for _, value := range values {
+			if plugin := value.(domain.Plugin); plugin.Name == name {
+				plugins = append(plugins, plugin)
+				found = true
+			}
+		}
+		if !found {
+			return nil, notFound("no plugin named %q%s", name, didYouMean(name, items))
+		}
+	}
+	return plugins, nil
+}
+
+// pluginContents returns the capabilities of one type that plugins
+// contribute, with the name of the plugin each comes from
+func pluginContents(plugins []domain.Plugin, kind domain.CapabilityType) (values []any, owners []string) {
+	for _, plugin := range plugins {
+		var items []any
+		switch kind {
+		case domain.TypeMCP:
+			items = anySlice(plugin.MCPServers)
+		case domain.TypeCommand:
+			items = anySlice(plugin.Commands)
+		case domain.TypeSkill:
+			items = anySlice(plugin.Skills)
+		case domain.TypeAgent:
+			items = anySlice(plugin.Agents)
+		}
+		values = append(values, items...)
+		for range items {
+			owners = append(owners, plugin.Name)
+		}
+	}
+	return values, owners
+}
+
+// MCPServerNames returns the names of the MCP servers configured in every
+// scope, which agents can reference as mcp__<server>__<tool>
+func (l CapabilityLoaders) MCPServerNames() ([]string, error) {
+	servers, err := l.Load(domain.TypeMCP, "")
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(servers))
+	for _, server := range servers {
+		names = append(names, server.GetName())
+	}
+	return names, nil
+}
+
+// Find returns the capability called name, which may be a command's
+// namespace:name. It fails when the name exists in several scopes and no
+// scope was given.
+func (l CapabilityLoaders) Find(kind domain.CapabilityType, name string, scope domain.CapabilityScope) (viewmodels.CapabilityViewModel, error) {
+	_, vm, err := l.FindDomain(kind, name, scope)
+	return vm, err
+}
+
+// FindDomain is Find also returning the domain value behind the view model
+func (l CapabilityLoaders) FindDomain(kind domain.CapabilityType, name string, scope domain.CapabilityScope) (any, viewmodels.CapabilityViewModel, error) {
+	values, items, err := l.load(kind, scope)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var matches []int
+	for i, item := range items {
+		if item.FilterValue() == name {
+			matches = append(matches, i)
+		}
+	}
+	if len(matches) == 0 {
+		for i, item := range items {
+			if item.GetName() == name {
+				matches = append(matches, i)
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil, l.notFoundError(kind, name, scope, items)
+	case 1:
+		return values[matches[0]], items[matches[0]], nil
+	default:
+		var where []string
+		for _, i := range matches {
+			where = append(where, fmt.Sprintf("%s (%s scope)", items[i].FilterValue(), items[i].GetScope()))
+		}
+		return nil, nil, exitCodeError{
+			code: exitUsage,
+			err:  fmt.Errorf("%q is ambiguous: %s; pass --scope or the full name", name, strings.Join(where, ", ")),
+		}
+	}
+}
+
+// notFoundError explains that no capability is called name, pointing at
+// other scopes that have it and at similar names
+func (l CapabilityLoaders) notFoundError(kind domain.CapabilityType, name string, scope domain.CapabilityScope, items []viewmodels.CapabilityViewModel) error {
+	if scope == "" {
+		return notFound("no %s named %q%s", kind, name, didYouMean(name, items))
+	}
+
+	if all, err := l.Load(kind, ""); err == nil {
+		var scopes []string
+		for _, item := range all {
+			if item.FilterValue() == name || item.GetName() == name {
+				scopes = append(scopes, string(item.GetScope()))
+			}
+		}
+		if len(scopes) > 0 {
+			return notFound("no %s named %q in %s scope; it exists in %s scope", kind, name, scope, strings.Join(scopes, " and "))
+		}
+	}
+	return notFound("no %s named %q in %s scope%s", kind, name, scope, didYouMean(name, items))
+}
+
+// didYouMean suggests names similar to name, or returns ""
+func didYouMean(name string, items []viewmodels.CapabilityViewModel) string {
+	candidates := make([]string, len(items))
+	for i, item := range items {
+		candidates[i] = item.FilterValue()
+	}
+	suggestions := domain.Suggest(name, candidates)
+	if len(suggestions) == 0 {
+		return ""
+	}
+	for i, suggestion := range suggestions {
+		suggestions[i] = strconv.Quote(suggestion)
+	}
+	return "; did you mean " + strings.Join(suggestions, " or ") + "?"
+}
+
+func loadAny[T any](loader loaders.Loader[T], scope domain.CapabilityScope) ([]any, error) {
+	items, err := loader.Load(scope)
+	if err != nil {
+		return nil, err
+	}
+	return anySlice(items), nil
+}
+
+func anySlice[T any](items []T) []any {
+	result := make([]any, len(items))
+	for i, item := range items {
+		result[i] = item
+	}
+	return result
+}
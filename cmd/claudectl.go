@@ -1,8 +1,10 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"os"
 	"time"
 
 	"go.uber.org/fx"
@@ -11,6 +13,7 @@ import (
 	"claudectl/internal/loaders"
 	"claudectl/internal/utils"
 	"claudectl/internal/view"
+	"claudectl/internal/writers"
 )
 
 const version = "0.1.0"
@@ -25,6 +28,7 @@ type Config struct {
 	ListPlugins  bool
 	ScopeFilter  string
 	JSONOutput   bool
+	Args         []string
 }
 
 func (c Config) IsNonInteractive() bool {
@@ -35,33 +39,47 @@ func ParseFlags() Config {
 	cfg := Config{}
 	flag.BoolVar(&cfg.Version, "version", false, "Show version")
 	flag.BoolVar(&cfg.Debug, "debug", false, "Enable debug logging")
-	flag.BoolVar(&cfg.ListMCPs, "list-mcps", false, "List MCP servers")
-	flag.BoolVar(&cfg.ListCommands, "list-commands", false, "List commands")
-	flag.BoolVar(&cfg.ListSkills, "list-skills", false, "List skills")
-	flag.BoolVar(&cfg.ListAgents, "list-agents", false, "List agents")
-	flag.BoolVar(&cfg.ListPlugins, "list-plugins", false, "List plugins")
-	flag.StringVar(&cfg.ScopeFilter, "scope", "all", "Scope filter: user|project|all")
-	flag.BoolVar(&cfg.JSONOutput, "json", false, "Output as JSON")
+	flag.BoolVar(&cfg.ListMCPs, "list-mcps", false, "Deprecated: use \"list mcp\"")
+	flag.BoolVar(&cfg.ListCommands, "list-commands", false, "Deprecated: use \"list command\"")
+	flag.BoolVar(&cfg.ListSkills, "list-skills", false, "Deprecated: use \"list skill\"")
+	flag.BoolVar(&cfg.ListAgents, "list-agents", false, "Deprecated: use \"list agent\"")
+	flag.BoolVar(&cfg.ListPlugins, "list-plugins", false, "Deprecated: use \"list plugin\"")
+	flag.StringVar(&cfg.ScopeFilter, "scope", "all", "Deprecated: use \"list --scope\"")
+	flag.BoolVar(&cfg.JSONOutput, "json", false, "Deprecated: use \"list --json\"")
+	flag.Usage = func() {
+		rootCommand().printUsage(os.Stderr)
+		fmt.Fprintln(os.Stderr, "\nGlobal flags:")
+		flag.PrintDefaults()
+	}
 	flag.Parse()
+	cfg.Args = flag.Args()
 	return cfg
 }
 
-func loadCapabilities[T any](
-	loader loaders.Loader[T],
-	scope domain.CapabilityScope,
-	capabilities *[]interface{},
-	logger *utils.Logger,
-	capabilityType string,
-) {
-	caps, err := loader.Load(scope)
-	if err != nil {
-		logger.Warn(fmt.Sprintf("failed to load %s %s", scope, capabilityType), "error", err)
-		return
+// deprecatedListArgs translates the old --list-* flags into the arguments
+// of the list command
+func (c Config) deprecatedListArgs() []string {
+	args := []string{"list"}
+	for _, f := range []struct {
+		set  bool
+		kind domain.CapabilityType
+	}{
+		{c.ListMCPs, domain.TypeMCP},
+		{c.ListCommands, domain.TypeCommand},
+		{c.ListSkills, domain.TypeSkill},
+		{c.ListAgents, domain.TypeAgent},
+		{c.ListPlugins, domain.TypePlugin},
+	} {
+		if f.set {
+			fmt.Fprintf(os.Stderr, "Warning: --list-%ss is deprecated, use \"claudectl list %s\"\n", f.kind, f.kind)
+			args = append(args, string(f.kind))
+		}
 	}
-	for _, cap := range caps {
-		c := cap
-		*capabilities = append(*capabilities, &c)
+	args = append(args, "--scope", c.ScopeFilter)
+	if c.JSONOutput {
+		args = append(args, "--json")
 	}
+	return args
 }
 
 func main() {
@@ -78,23 +96,61 @@ func main() {
 			return utils.NewLogger(lc, cfg.Debug)
 		}),
 		fx.Provide(
+			loaders.NewProblems,
 			loaders.NewMCPLoader,
 			loaders.NewCommandLoader,
 			loaders.NewSkillLoader,
 			loaders.NewAgentLoader,
 			loaders.NewPluginLoader,
 		),
+		fx.Provide(writers.NewMCPWriter, writers.NewCapabilityWriter),
 		fx.Provide(view.NewModel),
 		fx.StartTimeout(30 * time.Second),
 		fx.StopTimeout(30 * time.Second),
 		fx.NopLogger,
 	}
 
-	if cfg.IsNonInteractive() {
-		options = append(options, fx.Invoke(RunNonInteractive))
+	if len(cfg.Args) > 0 {
+		options = append(options, subcommandOption(cfg.Args))
+	} else if cfg.IsNonInteractive() {
+		options = append(options, subcommandOption(cfg.deprecatedListArgs()))
 	} else {
 		options = append(options, fx.Invoke(RunTUI))
 	}
 
 	fx.New(options...).Run()
 }
+
+// subcommandOption resolves the subcommand in args and returns the fx
+// options running it, exiting on usage errors
+func subcommandOption(args []string) fx.Option {
+	cmd, rest := findSubcommand(rootSubcommands(), args)
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "Error: unknown command %q\n\n", args[0])
+		rootCommand().printUsage(os.Stderr)
+		os.Exit(exitUsage)
+	}
+	if cmd.parse == nil {
+		if len(rest) > 0 && (rest[0] == "-h" || rest[0] == "--help") {
+			cmd.printUsage(os.Stdout)
+			os.Exit(exitOK)
+		}
+		if len(rest) > 0 {
+			fmt.Fprintf(os.Stderr, "Error: unknown command %q\n\n", rest[0])
+		}
+		cmd.printUsage(os.Stderr)
+		os.Exit(exitUsage)
+	}
+
+	option, err := cmd.parse(rest)
+	if errors.Is(err, flag.ErrHelp) {
+		cmd.printUsage(os.Stdout)
+		os.Exit(exitOK)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		cmd.printUsage(os.Stderr)
+		os.Exit(exitUsage)
+	}
+	return option
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/writers"
+)
+
+// subcommand is a CLI verb such as "mcp add". Group commands only hold
+// children; leaf commands parse their own arguments and return the fx
+// options that run them.
+type subcommand struct {
+	name     string
+	usage    string
+	summary  string
+	children []*subcommand
+	parse    func(args []string) (fx.Option, error)
+}
+
+// Exit codes shared by every command
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+	// exitNotFound means the command worked but nothing matched, so scripts
+	// can tell an empty result apart from a failure to load
+	exitNotFound = 3
+)
+
+// exitCodeError makes a command exit with a specific code. A nil err exits
+// without printing anything.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e exitCodeError) Error() string {
+	if e.err == nil {
+		return ""
+	}
+	return e.err.Error()
+}
+
+func (e exitCodeError) Unwrap() error {
+	return e.err
+}
+
+// errNothingFound exits with exitNotFound without an error message
+var errNothingFound = exitCodeError{code: exitNotFound}
+
+// notFound reports a missing capability, exiting with exitNotFound
+func notFound(format string, args ...any) error {
+	return exitCodeError{code: exitNotFound, err: fmt.Errorf(format, args...)}
+}
+
+// exitCode maps a command's error to the code the process exits with
+func exitCode(err error) int {
+	var exit exitCodeError
+	switch {
+	case errors.As(err, &exit):
+		return exit.code
+	case errors.Is(err, writers.ErrServerNotFound):
+		return exitNotFound
+	default:
+		return exitError
+	}
+}
+
+// rootCommand holds every top-level command for usage output
+func rootCommand() *subcommand {
+	return &subcommand{
+		usage:    "[--debug] [command] [args...]",
+		summary:  "Manage MCP servers, commands, skills, agents and plugins. Without a command, opens the TUI.",
+		children: rootSubcommands(),
+	}
+}
+
+func rootSubcommands() []*subcommand {
+	return []*subcommand{
+		listSubcommand(),
+		showSubcommand(),
+		searchSubcommand(),
+		doctorSubcommand(),
+		mcpSubcommand(),
+		pluginSubcommand(),
+		newSubcommand(),
+		editSubcommand(),
+		rmSubcommand(),
+		mvSubcommand(),
+		trashSubcommand(),
+		lintSubcommand(),
+		renderSubcommand(),
+		budgetSubcommand(),
+		schemaSubcommand(),
+		helpSubcommand(),
+	}
+}
+
+// findSubcommand resolves the longest chain of subcommand names at the start
+// of args and returns the matched command with the remaining arguments
+func findSubcommand(commands []*subcommand, args []string) (*subcommand, []string) {
+	if len(args) == 0 {
+		return nil, args
+	}
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+		if child, rest := findSubcommand(cmd.children, args[1:]); child != nil {
+			return child, rest
+		}
+		return cmd, args[1:]
+	}
+	return nil, args
+}
+
+func (c *subcommand) printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: claudectl %s\n", c.usage)
+	if c.summary != "" {
+		fmt.Fprintf(w, "\n%s\n", c.summary)
+	}
+	if len(c.children) > 0 {
+		fmt.Fprintln(w, "\nCommands:")
+		for _, child := range c.children {
+			fmt.Fprintf(w, "  %-10s %s\n", child.name, child.summary)
+		}
+		fmt.Fprintf(w, "\nRun \"claudectl help %s<command>\" for the flags of a command.\n", c.path())
+	}
+	if fs := c.flags(); fs != nil && hasFlags(fs) {
+		fmt.Fprintln(w, "\nFlags:")
+		fs.SetOutput(w)
+		fs.PrintDefaults()
+	}
+	if c.name == "" {
+		fmt.Fprintln(w, "\nExit codes:")
+		fmt.Fprintln(w, "  0  success")
+		fmt.Fprintln(w, "  1  error, e.g. a configuration file could not be loaded")
+		fmt.Fprintln(w, "  2  invalid command line")
+		fmt.Fprintln(w, "  3  nothing found")
+	}
+}
+
+// path is the command's full name followed by a space, taken from the
+// words of its usage before the first argument, or empty for the root
+func (c *subcommand) path() string {
+	var words []string
+	for _, word := range strings.Fields(c.usage) {
+		if strings.HasPrefix(word, "<") || strings.HasPrefix(word, "[") {
+			break
+		}
+		words = append(words, word)
+	}
+	if len(words) == 0 {
+		return ""
+	}
+	return strings.Join(words, " ") + " "
+}
+
+// lastFlagSet is the flag set most recently created by newFlagSet. Leaf
+// commands build their flags while parsing, so flags parses "-h" to
+// collect them for usage output.
+var lastFlagSet *flag.FlagSet
+
+// flags returns the flag set of a leaf command, or nil
+func (c *subcommand) flags() *flag.FlagSet {
+	if c.parse == nil {
+		return nil
+	}
+	lastFlagSet = nil
+	if _, err := c.parse([]string{"-h"}); !errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	return lastFlagSet
+}
+
+func hasFlags(fs *flag.FlagSet) bool {
+	found := false
+	fs.VisitAll(func(*flag.Flag) { found = true })
+	return found
+}
+
+// newFlagSet creates a flag set that reports errors instead of exiting
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Usage = func() {}
+	lastFlagSet = fs
+	return fs
+}
+
+// parseInterspersed parses flags that may appear before or after positional
+// arguments. Everything after a literal "--" is returned separately and
+// never treated as a flag.
+func parseInterspersed(fs *flag.FlagSet, args []string) (positional, rest []string, err error) {
+	for i, arg := range args {
+		if arg == "--" {
+			rest = args[i+1:]
+			args = args[:i]
+			break
+		}
+	}
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, nil, err
+		}
+		remaining := fs.Args()
+		if len(remaining) == 0 {
+			return positional, rest, nil
+		}
+		positional = append(positional, remaining[0])
+		args = remaining[1:]
+	}
+}
+
+// stringList is a repeatable string flag
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// flagsSet reports which flags were given explicitly
+func flagsSet(fs *flag.FlagSet) map[string]bool {
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	return set
+}
+
+// runCommand runs fn once the app has started and shuts it down with an
+// exit code reflecting the result
+func runCommand(lc fx.Lifecycle, shutdowner fx.Shutdowner, fn func() error) {
+	runCommandContext(lc, shutdowner, func(context.Context) error {
+		return fn()
+	})
+}
+
+// runCommandContext is runCommand for long-running commands. fn runs outside
+// the start hook, so it is not bound by the start timeout, and its context
+// is cancelled when the app stops, e.g. on Ctrl-C. Stopping waits for fn to
+// return so its deferred cleanup, like killing MCP servers, still runs.
+func runCommandContext(lc fx.Lifecycle, shutdowner fx.Shutdowner, fn func(ctx context.Context) error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				code := exitOK
+				if err := fn(ctx); err != nil {
+					code = exitCode(err)
+					if msg := err.Error(); msg != "" {
+						fmt.Fprintf(os.Stderr, "Error: %s\n", msg)
+					}
+				}
+				shutdowner.Shutdown(fx.ExitCode(code))
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	})
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/budget"
+	"claudectl/internal/domain"
+	"claudectl/internal/mcpclient"
+	"claudectl/internal/settings"
+	"claudectl/internal/viewmodels"
+)
+
+type BudgetOptions struct {
+	Scope   domain.CapabilityScope
+	Measure bool
+	Timeout time.Duration
+	JSON    bool
+}
+
+func budgetSubcommand() *subcommand {
+	return &subcommand{
+		name:    "budget",
+		usage:   "budget [--scope user|project|local] [--measure] [--timeout 30s] [--json]",
+		summary: "Estimate the context tokens each capability and memory file adds to a session",
+		parse:   parseBudget,
+	}
+}
+
+func parseBudget(args []string) (fx.Option, error) {
+	var opts BudgetOptions
+	var scope string
+
+	fs := newFlagSet("budget")
+	fs.StringVar(&scope, "scope", "", "Only count this scope: user|project|local")
+	fs.BoolVar(&opts.Measure, "measure", false, "Start every MCP server to measure its tool definitions first")
+	fs.DurationVar(&opts.Timeout, "timeout", 30*time.Second, "Timeout for each MCP server with --measure")
+	fs.BoolVar(&opts.JSON, "json", false, "Output as JSON")
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", positional[0])
+	}
+	if scope != "" {
+		if opts.Scope, err = domain.ParseScope(scope); err != nil {
+			return nil, err
+		}
+	}
+
+	return fx.Options(fx.Supply(opts), fx.Invoke(RunBudget)), nil
+}
+
+func RunBudget(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts BudgetOptions,
+	capabilities CapabilityLoaders,
+) {
+	runCommandContext(lc, shutdowner, func(ctx context.Context) error {
+		if opts.Measure {
+			if err := measureServers(ctx, capabilities, opts); err != nil {
+				return err
+			}
+		}
+
+		memory, err := budget.LoadMemoryFiles()
+		if err != nil {
+			return err
+		}
+		var items []viewmodels.CapabilityViewModel
+		for _, kind := range []domain.CapabilityType{domain.TypeMCP, domain.TypeCommand, domain.TypeSkill, domain.TypeAgent, domain.TypePlugin} {
+			loaded, err := capabilities.Load(kind, "")
+			if err != nil {
+				return err
+			}
+			items = append(items, loaded...)
+		}
+		measurements, err := budget.LoadMeasurements()
+		if err != nil {
+			return err
+		}
+
+		if opts.Scope != "" {
+			memory = filterMemory(memory, opts.Scope)
+			items = filterScope(items, opts.Scope)
+		}
+		s, err := settings.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		report := budget.New(memory, items, s, measurements)
+
+		if opts.JSON {
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+		printBudget(report)
+		return nil
+	})
+}
+
+// measureServers starts each MCP server, lists its tools and caches the
+// result for later budget runs and the TUI
+func measureServers(ctx context.Context, capabilities CapabilityLoaders, opts BudgetOptions) error {
+	servers, err := loadMCPServers(capabilities.MCP, opts.Scope, nil)
+	if err != nil {
+		return err
+	}
+
+	// Enabled plugins' servers run under the names Claude Code gives them
+	plugins, err := capabilities.LoadDomain(domain.TypePlugin, opts.Scope)
+	if err != nil {
+		return err
+	}
+	s, _ := settings.Load()
+	for _, value := range plugins {
+		plugin := value.(domain.Plugin)
+		if !s.PluginEnabled(plugin.Key) {
+			continue
+		}
+		for _, server := range plugin.MCPServers {
+			servers = append(servers, *plugin.LaunchableServer(server))
+		}
+	}
+
+	for _, server := range servers {
+		fmt.Fprintf(os.Stderr, "Measuring %s (%s)...\n", server.Name, server.Scope)
+		serverCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+		client, inspection, err := mcpclient.Inspect(serverCtx, server, nil)
+		cancel()
+		if err == nil {
+			client.Close()
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", server.Name, err)
+			continue
+		}
+		if err := budget.Record(server.Scope, server.Name, budget.FromInspection(server.Name, inspection)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func filterMemory(files []budget.MemoryFile, scope domain.CapabilityScope) []budget.MemoryFile {
+	var result []budget.MemoryFile
+	for _, file := range files {
+		if file.Scope == scope {
+			result = append(result, file)
+		}
+	}
+	return result
+}
+
+func filterScope(items []viewmodels.CapabilityViewModel, scope domain.CapabilityScope) []viewmodels.CapabilityViewModel {
+	var result []viewmodels.CapabilityViewModel
+	for _, item := range items {
+		if item.GetScope() == scope {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func printBudget(report *budget.Report) {
+	if len(report.Items) == 0 {
+		fmt.Println("Nothing is loaded into the context")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "~TOKENS\tKIND\tNAME\tSCOPE\tLOADED")
+	for _, item := range report.Items {
+		cost := fmt.Sprint(item.Tokens)
+		if !item.Measured {
+			cost = "?"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", cost, item.Kind, item.Label(), item.Scope, item.Loading)
+	}
+	w.Flush()
+
+	fmt.Printf("\nTotal ~%d tokens", report.Total)
+	var scopes []string
+	for _, scope := range []domain.CapabilityScope{domain.ScopeUser, domain.ScopeProject, domain.ScopeLocal} {
+		if n, ok := report.Scopes[scope]; ok {
+			scopes = append(scopes, fmt.Sprintf("%s ~%d", scope, n))
+		}
+	}
+	if len(scopes) > 0 {
+		fmt.Printf(" (%s)", strings.Join(scopes, ", "))
+	}
+	fmt.Println()
+	if report.Unmeasured > 0 {
+		fmt.Printf("%d MCP server(s) not measured yet; run `claudectl budget --measure` or `claudectl mcp bench`\n", report.Unmeasured)
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/lint"
+	"claudectl/internal/mcpaudit"
+	"claudectl/internal/utils"
+)
+
+func doctorSubcommand() *subcommand {
+	return &subcommand{
+		name:    "doctor",
+		usage:   "doctor",
+		summary: "Check that configuration files load and capabilities are valid",
+		parse:   parseDoctor,
+	}
+}
+
+func parseDoctor(args []string) (fx.Option, error) {
+	fs := newFlagSet("doctor")
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", positional[0])
+	}
+	return fx.Invoke(RunDoctor), nil
+}
+
+type checkStatus int
+
+const (
+	checkOK checkStatus = iota
+	checkWarning
+	checkError
+)
+
+func (s checkStatus) symbol() string {
+	switch s {
+	case checkWarning:
+		return "⚠"
+	case checkError:
+		return "✗"
+	default:
+		return "✓"
+	}
+}
+
+// doctor collects check results and counts the failed ones
+type doctor struct {
+	errors   int
+	warnings int
+}
+
+func (d *doctor) report(status checkStatus, format string, args ...any) {
+	switch status {
+	case checkWarning:
+		d.warnings++
+	case checkError:
+		d.errors++
+	}
+	fmt.Printf("%s %s\n", status.symbol(), fmt.Sprintf(format, args...))
+}
+
+// RunDoctor checks the setup and fails when any check finds an error
+func RunDoctor(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	capabilities CapabilityLoaders,
+) {
+	runCommand(lc, shutdowner, func() error {
+		var d doctor
+
+		if _, err := exec.LookPath("claude"); err != nil {
+			d.report(checkWarning, "claude is not on PATH")
+		} else {
+			d.report(checkOK, "claude is on PATH")
+		}
+
+		d.checkLoaders(capabilities)
+		d.checkMCPServers(capabilities)
+		d.checkLint(capabilities)
+
+		if d.errors > 0 {
+			return fmt.Errorf("%d error(s), %d warning(s)", d.errors, d.warnings)
+		}
+		fmt.Fprintf(os.Stderr, "No errors, %d warning(s)\n", d.warnings)
+		return nil
+	})
+}
+
+// checkLoaders loads every type and prints the counts per scope
+func (d *doctor) checkLoaders(capabilities CapabilityLoaders) {
+	counts := map[domain.CapabilityScope][]string{}
+	// Agents load MCP servers too, so the same error can come up twice
+	failed := map[string]bool{}
+	for _, kind := range capabilityTypes {
+		items, err := capabilities.Load(kind, "")
+		if err != nil {
+			if !failed[err.Error()] {
+				d.report(checkError, "%v", err)
+			}
+			failed[err.Error()] = true
+			continue
+		}
+		perScope := map[domain.CapabilityScope]int{}
+		for _, item := range items {
+			perScope[item.GetScope()]++
+		}
+		for _, scope := range []domain.CapabilityScope{domain.ScopeUser, domain.ScopeProject, domain.ScopeLocal} {
+			if n := perScope[scope]; n > 0 {
+				counts[scope] = append(counts[scope], fmt.Sprintf("%d %s(s)", n, kind))
+			}
+		}
+	}
+	for _, scope := range []domain.CapabilityScope{domain.ScopeUser, domain.ScopeProject, domain.ScopeLocal} {
+		if len(counts[scope]) > 0 {
+			d.report(checkOK, "%s scope: %s", scope, strings.Join(counts[scope], ", "))
+		}
+	}
+}
+
+// checkMCPServers reports the audit findings of local MCP servers
+func (d *doctor) checkMCPServers(capabilities CapabilityLoaders) {
+	values, err := capabilities.LoadDomain(domain.TypeMCP, "")
+	if err != nil {
+		// Already reported by checkLoaders
+		return
+	}
+	var servers []domain.MCPServer
+	for _, value := range values {
+		servers = append(servers, value.(domain.MCPServer))
+	}
+	problems := 0
+	for _, report := range mcpaudit.AuditAll(servers) {
+		for _, f := range report.Findings {
+			switch f.Severity {
+			case mcpaudit.SeverityError:
+				d.report(checkError, "MCP server %s (%s): %s", report.Server, report.Scope, f.Message)
+			case mcpaudit.SeverityWarning:
+				d.report(checkWarning, "MCP server %s (%s): %s", report.Server, report.Scope, f.Message)
+			default:
+				continue
+			}
+			problems++
+		}
+	}
+	if problems == 0 && len(servers) > 0 {
+		d.report(checkOK, "%d MCP server(s) passed the audit", len(servers))
+	}
+}
+
+// checkLint lints the user and project .claude directories
+func (d *doctor) checkLint(capabilities CapabilityLoaders) {
+	var dirs []string
+	for _, get := range []func() (string, error){utils.GetUserClaudeDir, utils.GetProjectClaudeDir} {
+		dir, err := get()
+		if err != nil {
+			d.report(checkError, "%v", err)
+			continue
+		}
+		if _, err := os.Stat(dir); err == nil {
+			dirs = append(dirs, dir)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			d.report(checkError, "%v", err)
+		}
+	}
+	if len(dirs) == 0 {
+		return
+	}
+
+	// An unreadable MCP config only skips the server check
+	servers, _ := capabilities.MCPServerNames()
+	report, err := lint.Run(dirs, lint.Options{MCPServers: servers})
+	if err != nil {
+		d.report(checkError, "lint: %v", err)
+		return
+	}
+	status := checkOK
+	switch {
+	case report.Errors > 0:
+		status = checkError
+	case report.Warnings > 0:
+		status = checkWarning
+	}
+	d.report(status, "lint: %s", summarizeLint(report))
+	if status != checkOK {
+		fmt.Printf("  run \"claudectl lint %s\" for details\n", strings.Join(dirs, " "))
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/editor"
+)
+
+type EditOptions struct {
+	Type  domain.CapabilityType
+	Name  string
+	Scope domain.CapabilityScope
+}
+
+func editSubcommand() *subcommand {
+	return &subcommand{
+		name:    "edit",
+		usage:   "edit <mcp|command|skill|agent|plugin> <name> [--scope user|project|local]",
+		summary: "Open a capability in $VISUAL or $EDITOR",
+		parse:   parseEdit,
+	}
+}
+
+func parseEdit(args []string) (fx.Option, error) {
+	var opts EditOptions
+	var scope string
+
+	fs := newFlagSet("edit")
+	fs.StringVar(&scope, "scope", "", "Scope: user|project|local (required when the name exists in several)")
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) != 2 {
+		return nil, fmt.Errorf("expected a type and a name")
+	}
+	if opts.Type, err = parseCapabilityType(positional[0]); err != nil {
+		return nil, err
+	}
+	opts.Name = positional[1]
+	if scope != "" {
+		if opts.Scope, err = domain.ParseScope(scope); err != nil {
+			return nil, err
+		}
+	}
+
+	return fx.Options(fx.Supply(opts), fx.Invoke(RunEdit)), nil
+}
+
+func RunEdit(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts EditOptions,
+	capabilities CapabilityLoaders,
+) {
+	// The editor can stay open longer than the start timeout
+	runCommandContext(lc, shutdowner, func(context.Context) error {
+		vm, err := capabilities.Find(opts.Type, opts.Name, opts.Scope)
+		if err != nil {
+			return err
+		}
+		path, line := editor.Location(vm)
+		if path == "" {
+			return fmt.Errorf("%s %q has no file to edit", opts.Type, opts.Name)
+		}
+
+		cmd, err := editor.Command(path, line)
+		if err != nil {
+			return err
+		}
+		return cmd.Run()
+	})
+}
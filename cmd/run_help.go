@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/fx"
+)
+
+type HelpOptions struct {
+	Command []string
+}
+
+func helpSubcommand() *subcommand {
+	return &subcommand{
+		name:    "help",
+		usage:   "help [command...]",
+		summary: "Show the usage and flags of a command",
+		parse:   parseHelp,
+	}
+}
+
+func parseHelp(args []string) (fx.Option, error) {
+	fs := newFlagSet("help")
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	return fx.Options(fx.Supply(HelpOptions{Command: positional}), fx.Invoke(RunHelp)), nil
+}
+
+func RunHelp(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts HelpOptions,
+) {
+	runCommand(lc, shutdowner, func() error {
+		if len(opts.Command) == 0 {
+			rootCommand().printUsage(os.Stdout)
+			return nil
+		}
+		cmd, rest := findSubcommand(rootSubcommands(), opts.Command)
+		if cmd == nil || len(rest) > 0 {
+			return exitCodeError{code: exitUsage, err: fmt.Errorf("unknown command %q", strings.Join(opts.Command, " "))}
+		}
+		cmd.printUsage(os.Stdout)
+		return nil
+	})
+}
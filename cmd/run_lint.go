@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/lint"
+	"claudectl/internal/utils"
+)
+
+type LintOptions struct {
+	Paths  []string
+	Format string
+	Strict bool
+}
+
+func lintSubcommand() *subcommand {
+	return &subcommand{
+		name:    "lint",
+		usage:   "lint [PATH...] [--format text|json|sarif] [--strict]",
+		summary: "Check command, skill and agent files (default: the project's .claude directory)",
+		parse:   parseLint,
+	}
+}
+
+func parseLint(args []string) (fx.Option, error) {
+	var opts LintOptions
+
+	fs := newFlagSet("lint")
+	fs.StringVar(&opts.Format, "format", "text", "Output format: text|json|sarif")
+	fs.BoolVar(&opts.Strict, "strict", false, "Exit with an error on warnings too")
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	switch opts.Format {
+	case "text", "json", "sarif":
+	default:
+		return nil, fmt.Errorf("invalid format %q (expected text, json or sarif)", opts.Format)
+	}
+	opts.Paths = positional
+
+	return fx.Options(fx.Supply(opts), fx.Invoke(RunLint)), nil
+}
+
+func RunLint(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts LintOptions,
+	capabilities CapabilityLoaders,
+) {
+	runCommand(lc, shutdowner, func() error {
+		paths := opts.Paths
+		if len(paths) == 0 {
+			dir, err := utils.GetProjectClaudeDir()
+			if err != nil {
+				return err
+			}
+			paths = []string{dir}
+		}
+
+		// An unreadable MCP config only skips the server check
+		servers, _ := capabilities.MCPServerNames()
+
+		report, err := lint.Run(paths, lint.Options{MCPServers: servers})
+		if err != nil {
+			return err
+		}
+
+		switch opts.Format {
+		case "json":
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+		case "sarif":
+			data, err := report.SARIF(version)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+		default:
+			for _, d := range report.Diagnostics {
+				fmt.Println(d)
+			}
+		}
+
+		if report.Errors > 0 || (opts.Strict && report.Warnings > 0) {
+			return errors.New(summarizeLint(report))
+		}
+		if opts.Format == "text" {
+			fmt.Fprintln(os.Stderr, summarizeLint(report))
+		}
+		return nil
+	})
+}
+
+func summarizeLint(report *lint.Report) string {
+	summary := fmt.Sprintf("%d file(s) checked: %d error(s), %d warning(s)", report.Files, report.Errors, report.Warnings)
+	if report.Notes > 0 {
+		summary += fmt.Sprintf(", %d note(s)", report.Notes)
+	}
+	return summary
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/output"
+	"claudectl/internal/query"
+	"claudectl/internal/viewmodels"
+)
+
+type ListOptions struct {
+	Query query.Query
+	// Plugins are names of plugins whose capabilities are listed
+	Plugins stringList
+	Output  output.Options
+}
+
+func listSubcommand() *subcommand {
+	return &subcommand{
+		name:    "list",
+		usage:   "list [mcp|command|skill|agent|plugin...] [--type T,...] [--scope user|project|local|all] [--name GLOB|/REGEX/] [--namespace NS] [--grep TEXT] [--plugin NAME]... [--output FORMAT] [--fields a,b,...] [--sort a,b,...] [--template TEXT]",
+		summary: "List capabilities (default: every type)",
+		parse:   parseList,
+	}
+}
+
+func parseList(args []string) (fx.Option, error) {
+	return parseListOptions("list", args, nil)
+}
+
+// parseListOptions parses the list flags. A non-nil kinds fixes the types
+// listed, for commands such as "plugin list".
+func parseListOptions(name string, args []string, kinds []domain.CapabilityType) (fx.Option, error) {
+	var opts ListOptions
+	var scope, namePattern string
+	var types stringList
+
+	fs := newFlagSet(name)
+	fs.StringVar(&scope, "scope", "all", "Scope: user|project|local|all")
+	fs.Var(&types, "type", "Only list these types, comma-separated (repeatable)")
+	fs.StringVar(&namePattern, "name", "", "Only list names matching a glob, or a regular expression between slashes")
+	fs.StringVar(&opts.Query.Namespace, "namespace", "", "Only list commands in this namespace, including nested ones")
+	fs.StringVar(&opts.Query.Grep, "grep", "", "Only list capabilities whose description or content contains this text")
+	fs.Var(&opts.Plugins, "plugin", "List the capabilities this plugin contributes (repeatable)")
+	bindOutputFlags(fs, &opts.Output)
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := opts.Output.Validate(); err != nil {
+		return nil, err
+	}
+	if kinds != nil && len(positional)+len(types) > 0 {
+		return nil, fmt.Errorf("types cannot be given; this command only lists %ss", kinds[0])
+	}
+	for _, t := range types {
+		positional = append(positional, strings.Split(t, ",")...)
+	}
+	for _, arg := range positional {
+		kind, err := parseCapabilityType(strings.TrimSpace(arg))
+		if err != nil {
+			return nil, err
+		}
+		if !containsType(opts.Query.Types, kind) {
+			opts.Query.Types = append(opts.Query.Types, kind)
+		}
+	}
+	if len(opts.Query.Types) == 0 {
+		opts.Query.Types = capabilityTypes
+		if kinds != nil {
+			opts.Query.Types = kinds
+		}
+	}
+	if opts.Query.Scope, err = parseScopeFilter(scope); err != nil {
+		return nil, err
+	}
+	if namePattern != "" {
+		if opts.Query.Name, err = query.ParsePattern(namePattern); err != nil {
+			return nil, err
+		}
+	}
+
+	return fx.Options(fx.Supply(opts), fx.Invoke(RunList)), nil
+}
+
+// fieldsFlag parses a comma-separated list of record fields
+type fieldsFlag struct {
+	fields *[]string
+}
+
+func (f fieldsFlag) String() string {
+	if f.fields == nil {
+		return ""
+	}
+	return strings.Join(*f.fields, ",")
+}
+
+func (f fieldsFlag) Set(value string) error {
+	fields, err := output.ParseFields(value)
+	if err != nil {
+		return err
+	}
+	*f.fields = fields
+	return nil
+}
+
+// jsonFlag is the --json shorthand for --output json
+type jsonFlag struct {
+	format *string
+}
+
+func (f jsonFlag) String() string   { return "" }
+func (f jsonFlag) IsBoolFlag() bool { return true }
+
+func (f jsonFlag) Set(value string) error {
+	set, err := strconv.ParseBool(value)
+	if err == nil && set {
+		*f.format = output.FormatJSON
+	}
+	return err
+}
+
+// templateFlag sets --template and switches the output to it
+type templateFlag struct {
+	opts *output.Options
+}
+
+func (f templateFlag) String() string { return "" }
+
+func (f templateFlag) Set(value string) error {
+	f.opts.Template = value
+	if f.opts.Format == output.FormatTable {
+		f.opts.Format = output.FormatTemplate
+	}
+	return nil
+}
+
+func bindOutputFlags(fs *flag.FlagSet, opts *output.Options) {
+	opts.Format = output.FormatTable
+	fs.StringVar(&opts.Format, "output", output.FormatTable, "Output format: "+strings.Join(output.Formats, "|"))
+	fs.StringVar(&opts.Format, "o", output.FormatTable, "Shorthand for --output")
+	fs.Var(jsonFlag{&opts.Format}, "json", "Shorthand for --output json")
+	fs.Var(fieldsFlag{&opts.Fields}, "fields", "Comma-separated fields or columns to print (see \"claudectl schema\")")
+	fs.Var(fieldsFlag{&opts.Sort}, "sort", "Comma-separated fields to sort by, e.g. name,scope,type")
+	fs.Var(templateFlag{opts}, "template", "Go template printed for each capability, e.g. '{{.Name}}\\t{{.Scope}}'")
+}
+
+// parseScopeFilter validates a --scope filter, where "all" matches every
+// scope and returns the empty scope
+func parseScopeFilter(s string) (domain.CapabilityScope, error) {
+	if s == "all" {
+		return "", nil
+	}
+	scope, err := domain.ParseScope(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid scope %q (expected user, project, local or all)", s)
+	}
+	return scope, nil
+}
+
+func containsType(types []domain.CapabilityType, kind domain.CapabilityType) bool {
+	for _, t := range types {
+		if t == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// RunList prints the capabilities matching the query. A type that fails
+// to load fails the command, and an empty result exits with exitNotFound.
+func RunList(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts ListOptions,
+	capabilities CapabilityLoaders,
+) {
+	runCommand(lc, shutdowner, func() error {
+		q := opts.Query
+		var plugins []domain.Plugin
+		if len(opts.Plugins) > 0 {
+			var err error
+			if plugins, err = capabilities.FindPlugins(opts.Plugins, q.Scope); err != nil {
+				return err
+			}
+			for _, plugin := range plugins {
+				q.PluginDirs = append(q.PluginDirs, plugin.Path)
+			}
+		}
+
+		writer, err := output.NewWriter(os.Stdout, opts.Output)
+		if err != nil {
+			return err
+		}
+
+		found := 0
+		for _, kind := range q.Types {
+			values, loaded, err := capabilities.load(kind, q.Scope)
+			if err != nil {
+				return err
+			}
+			owners := make([]string, len(values))
+
+			// Plugin capabilities are only listed when asked for
+			contents, contentOwners := pluginContents(plugins, kind)
+			for i, value := range contents {
+				vm, err := viewmodels.ToDomainViewModel(value)
+				if err != nil {
+					return err
+				}
+				values, loaded = append(values, value), append(loaded, vm)
+				owners = append(owners, contentOwners[i])
+			}
+
+			for i, value := range values {
+				if !q.Match(loaded[i]) {
+					continue
+				}
+				found++
+				record, err := newRecord(value, loaded[i])
+				if err != nil {
+					return err
+				}
+				record.Plugin = owners[i]
+				if err := writer.Write(record); err != nil {
+					return err
+				}
+			}
+		}
+
+		if found == 0 && opts.Output.Format == output.FormatTable {
+			fmt.Fprintln(os.Stderr, "No capabilities found")
+			return errNothingFound
+		}
+		if err := writer.Close(); err != nil {
+			return err
+		}
+		if found == 0 {
+			return errNothingFound
+		}
+		return nil
+	})
+}
+
+// newRecord converts a loaded capability to the output schema, with the
+// warnings its view model reports
+func newRecord(value any, vm viewmodels.CapabilityViewModel) (output.Record, error) {
+	record, err := output.NewRecord(value)
+	if err != nil {
+		return record, err
+	}
+	if wp, ok := vm.(viewmodels.WarningProvider); ok {
+		record.Warnings = wp.Warnings()
+	}
+	return record, nil
+}
+
+func printJSON(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("formatting JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/loaders"
+	"claudectl/internal/utils"
+	"claudectl/internal/writers"
+)
+
+// MCPServerOptions holds the server fields given on the command line
+type MCPServerOptions struct {
+	Name          string
+	Scope         domain.CapabilityScope
+	Type          string
+	URL           string
+	HeadersHelper string
+	Timeout       int
+	Args          stringList
+	Env           stringList
+	UnsetEnv      stringList
+	Headers       stringList
+	UnsetHeaders  stringList
+	CommandLine   []string
+
+	set map[string]bool
+}
+
+type MCPAddOptions struct {
+	MCPServerOptions
+}
+
+type MCPEditOptions struct {
+	MCPServerOptions
+}
+
+type MCPRemoveOptions struct {
+	Name  string
+	Scope domain.CapabilityScope
+}
+
+func mcpSubcommand() *subcommand {
+	cmd := &subcommand{
+		name:    "mcp",
+		usage:   "mcp <command> [flags]",
+		summary: "Manage MCP servers",
+		children: []*subcommand{
+			{
+				name:    "add",
+				usage:   "mcp add <name> [--scope user|project|local] [--type stdio|sse|http] [--url URL] [--env K=V]... [--header 'K: V']... [-- command [args...]]",
+				summary: "Add an MCP server",
+				parse:   parseMCPAdd,
+			},
+			{
+				name:    "edit",
+				usage:   "mcp edit <name> [--scope user|project|local] [flags] [-- command [args...]]",
+				summary: "Change fields of an existing MCP server",
+				parse:   parseMCPEdit,
+			},
+			{
+				name:    "remove",
+				usage:   "mcp remove <name> [--scope user|project|local]",
+				summary: "Remove an MCP server",
+				parse:   parseMCPRemove,
+			},
+		},
+	}
+	cmd.children = append(cmd.children, mcpTransferSubcommands()...)
+	cmd.children = append(cmd.children, mcpImportSubcommand(), mcpExportSubcommand(), mcpAuditSubcommand(), mcpBenchSubcommand(), mcpTraceSubcommand())
+	return cmd
+}
+
+func bindServerFlags(fs *flag.FlagSet, opts *MCPServerOptions, scope *string) {
+	fs.StringVar(scope, "scope", "", "Scope: user|project|local")
+	fs.StringVar(&opts.Type, "type", "", "Transport type: stdio|sse|http")
+	fs.StringVar(&opts.URL, "url", "", "Server URL for sse/http servers")
+	fs.StringVar(&opts.HeadersHelper, "headers-helper", "", "Command printing extra headers as JSON")
+	fs.IntVar(&opts.Timeout, "timeout", 0, "Timeout in milliseconds")
+	fs.Var(&opts.Args, "arg", "Command argument (repeatable)")
+	fs.Var(&opts.Env, "env", "Environment variable K=V (repeatable)")
+	fs.Var(&opts.UnsetEnv, "unset-env", "Remove an environment variable (repeatable)")
+	fs.Var(&opts.Headers, "header", "HTTP header 'Name: value' (repeatable)")
+	fs.Var(&opts.UnsetHeaders, "unset-header", "Remove an HTTP header (repeatable)")
+}
+
+func parseServerOptions(name string, args []string) (MCPServerOptions, error) {
+	var opts MCPServerOptions
+	var scope string
+
+	fs := newFlagSet(name)
+	bindServerFlags(fs, &opts, &scope)
+	positional, rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return opts, err
+	}
+	if len(positional) != 1 {
+		return opts, fmt.Errorf("expected exactly one server name, got %d", len(positional))
+	}
+
+	opts.Name = positional[0]
+	opts.CommandLine = rest
+	opts.set = flagsSet(fs)
+	if scope != "" {
+		if opts.Scope, err = domain.ParseScope(scope); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
+
+func parseMCPAdd(args []string) (fx.Option, error) {
+	opts, err := parseServerOptions("add", args)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateMCPServerName(opts.Name); err != nil {
+		return nil, err
+	}
+	return fx.Options(fx.Supply(MCPAddOptions{opts}), fx.Invoke(RunMCPAdd)), nil
+}
+
+func parseMCPEdit(args []string) (fx.Option, error) {
+	opts, err := parseServerOptions("edit", args)
+	if err != nil {
+		return nil, err
+	}
+	return fx.Options(fx.Supply(MCPEditOptions{opts}), fx.Invoke(RunMCPEdit)), nil
+}
+
+func parseMCPRemove(args []string) (fx.Option, error) {
+	var scope string
+	fs := newFlagSet("remove")
+	fs.StringVar(&scope, "scope", "", "Scope: user|project|local")
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) != 1 {
+		return nil, fmt.Errorf("expected exactly one server name, got %d", len(positional))
+	}
+
+	opts := MCPRemoveOptions{Name: positional[0]}
+	if scope != "" {
+		if opts.Scope, err = domain.ParseScope(scope); err != nil {
+			return nil, err
+		}
+	}
+	return fx.Options(fx.Supply(opts), fx.Invoke(RunMCPRemove)), nil
+}
+
+func RunMCPAdd(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts MCPAddOptions,
+	mcpWriter writers.MCPWriter,
+	logger *utils.Logger,
+) {
+	runCommand(lc, shutdowner, func() error {
+		scope := opts.Scope
+		if scope == "" {
+			scope = domain.ScopeLocal
+		}
+
+		server := domain.NewMCPServer(domain.MCPServerParams{Name: opts.Name, Scope: scope})
+		if !opts.set["type"] {
+			server.MCPType = string(domain.TransportStdio)
+			if opts.URL != "" {
+				server.MCPType = string(domain.TransportHTTP)
+			}
+		}
+		if err := opts.apply(server); err != nil {
+			return err
+		}
+		if problems := server.ConfigProblems(); len(problems) > 0 {
+			return fmt.Errorf("invalid server config: %s", strings.Join(problems, "; "))
+		}
+
+		if err := mcpWriter.Add(*server); err != nil {
+			return err
+		}
+		logger.Info("added MCP server", "name", server.Name, "scope", scope)
+		fmt.Printf("Added MCP server %q to %s scope\n", server.Name, scope)
+		return nil
+	})
+}
+
+func RunMCPEdit(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts MCPEditOptions,
+	mcpLoader loaders.MCPLoader,
+	mcpWriter writers.MCPWriter,
+	logger *utils.Logger,
+) {
+	runCommand(lc, shutdowner, func() error {
+		original, err := findMCPServer(mcpLoader, opts.Name, opts.Scope)
+		if err != nil {
+			return err
+		}
+
+		updated := original.Clone()
+		if err := opts.apply(updated); err != nil {
+			return err
+		}
+		if problems := updated.ConfigProblems(); len(problems) > 0 {
+			return fmt.Errorf("invalid server config: %s", strings.Join(problems, "; "))
+		}
+
+		if err := mcpWriter.Update(*original, *updated); err != nil {
+			return err
+		}
+		logger.Info("updated MCP server", "name", updated.Name, "scope", updated.Scope)
+		fmt.Printf("Updated MCP server %q in %s scope\n", updated.Name, updated.Scope)
+		return nil
+	})
+}
+
+func RunMCPRemove(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts MCPRemoveOptions,
+	mcpLoader loaders.MCPLoader,
+	mcpWriter writers.MCPWriter,
+	logger *utils.Logger,
+) {
+	runCommand(lc, shutdowner, func() error {
+		server, err := findMCPServer(mcpLoader, opts.Name, opts.Scope)
+		if err != nil {
+			return err
+		}
+
+		if err := mcpWriter.Remove(*server); err != nil {
+			return err
+		}
+		logger.Info("removed MCP server", "name", server.Name, "scope", server.Scope)
+		fmt.Printf("Removed MCP server %q from %s scope\n", server.Name, server.Scope)
+		return nil
+	})
+}
+
+// apply copies the fields given on the command line onto server
+func (o MCPServerOptions) apply(server *domain.MCPServer) error {
+	if o.set["type"] {
+		server.MCPType = o.Type
+	}
+	if o.set["url"] {
+		server.Url = o.URL
+	}
+	if len(o.CommandLine) > 0 {
+		server.Command = o.CommandLine[0]
+		server.Args = append([]string(nil), o.CommandLine[1:]...)
+	}
+	if o.set["arg"] {
+		if len(o.CommandLine) == 0 {
+			server.Args = nil
+		}
+		server.Args = append(server.Args, o.Args...)
+	}
+	if o.set["headers-helper"] {
+		server.HeadersHelper = o.HeadersHelper
+	}
+	if o.set["timeout"] {
+		server.Timeout = o.Timeout
+	}
+
+	for _, env := range o.Env {
+		key, value, ok := strings.Cut(env, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("invalid --env %q (expected KEY=value)", env)
+		}
+		if server.Env == nil {
+			server.Env = map[string]string{}
+		}
+		server.Env[key] = value
+	}
+	for _, key := range o.UnsetEnv {
+		delete(server.Env, key)
+	}
+
+	for _, header := range o.Headers {
+		name, value, ok := strings.Cut(header, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("invalid --header %q (expected 'Name: value')", header)
+		}
+		if server.Headers == nil {
+			server.Headers = map[string]string{}
+		}
+		server.Headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	for _, name := range o.UnsetHeaders {
+		delete(server.Headers, name)
+	}
+
+	return nil
+}
+
+// findMCPServer looks a server up by name, requiring a scope when the name
+// is defined in more than one
+func findMCPServer(loader loaders.MCPLoader, name string, scope domain.CapabilityScope) (*domain.MCPServer, error) {
+	var matches []domain.MCPServer
+	for _, s := range []domain.CapabilityScope{domain.ScopeUser, domain.ScopeProject} {
+		servers, err := loader.Load(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s MCP servers: %w", s, err)
+		}
+		for _, server := range servers {
+			if server.Name == name && (scope == "" || server.Scope == scope) {
+				matches = append(matches, server)
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		if scope != "" {
+			return nil, fmt.Errorf("%w: %s (%s)", writers.ErrServerNotFound, name, scope)
+		}
+		return nil, fmt.Errorf("%w: %s", writers.ErrServerNotFound, name)
+	case 1:
+		return &matches[0], nil
+	default:
+		var scopes []string
+		for _, m := range matches {
+			scopes = append(scopes, string(m.Scope))
+		}
+		return nil, fmt.Errorf("%q exists in several scopes (%s); pass --scope", name, strings.Join(scopes, ", "))
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/loaders"
+	"claudectl/internal/mcpaudit"
+)
+
+type MCPAuditOptions struct {
+	Names  []string
+	Scope  domain.CapabilityScope
+	JSON   bool
+	Strict bool
+}
+
+func mcpAuditSubcommand() *subcommand {
+	return &subcommand{
+		name:    "audit",
+		usage:   "mcp audit [NAME...] [--scope user|project|local] [--json] [--strict]",
+		summary: "Check that server commands resolve and launched packages are pinned",
+		parse:   parseMCPAudit,
+	}
+}
+
+func parseMCPAudit(args []string) (fx.Option, error) {
+	var opts MCPAuditOptions
+	var scope string
+
+	fs := newFlagSet("audit")
+	fs.StringVar(&scope, "scope", "", "Only audit this scope: user|project|local")
+	fs.BoolVar(&opts.JSON, "json", false, "Output as JSON")
+	fs.BoolVar(&opts.Strict, "strict", false, "Exit with an error on warnings too")
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if scope != "" {
+		if opts.Scope, err = domain.ParseScope(scope); err != nil {
+			return nil, err
+		}
+	}
+	opts.Names = positional
+
+	return fx.Options(fx.Supply(opts), fx.Invoke(RunMCPAudit)), nil
+}
+
+func RunMCPAudit(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts MCPAuditOptions,
+	mcpLoader loaders.MCPLoader,
+) {
+	runCommand(lc, shutdowner, func() error {
+		servers, err := loadMCPServers(mcpLoader, opts.Scope, opts.Names)
+		if err != nil {
+			return err
+		}
+		reports := mcpaudit.AuditAll(servers)
+
+		if opts.JSON {
+			data, err := json.MarshalIndent(reports, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+		} else {
+			printAuditTable(reports)
+		}
+
+		failed := 0
+		for _, report := range reports {
+			status := report.Status()
+			if status == string(mcpaudit.SeverityError) || (opts.Strict && status == string(mcpaudit.SeverityWarning)) {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d server(s) failed the audit", failed)
+		}
+		return nil
+	})
+}
+
+func printAuditTable(reports []mcpaudit.Report) {
+	if len(reports) == 0 {
+		fmt.Println("No MCP servers found")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSCOPE\tSTATUS\tCOMMAND\tPACKAGE")
+	for _, report := range reports {
+		command := report.ResolvedCommand
+		if command == "" {
+			command = report.Command
+		}
+		pkg := "-"
+		if report.Package != nil {
+			pkg = report.Package.String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", report.Server, report.Scope, report.Summary(), command, pkg)
+	}
+	w.Flush()
+
+	for _, report := range reports {
+		for _, finding := range report.Findings {
+			fmt.Printf("%s (%s): %s: %s\n", report.Server, report.Scope, finding.Severity, finding.Message)
+		}
+	}
+}
+
+// loadMCPServers loads the servers of one scope, or of every scope when
+// scope is empty, optionally restricted to names
+func loadMCPServers(mcpLoader loaders.MCPLoader, scope domain.CapabilityScope, names []string) ([]domain.MCPServer, error) {
+	scopes := []domain.CapabilityScope{domain.ScopeUser, domain.ScopeProject}
+	if scope == domain.ScopeLocal {
+		scopes = []domain.CapabilityScope{domain.ScopeProject}
+	} else if scope != "" {
+		scopes = []domain.CapabilityScope{scope}
+	}
+
+	var servers []domain.MCPServer
+	found := map[string]bool{}
+	for _, s := range scopes {
+		loaded, err := mcpLoader.Load(s)
+		if err != nil {
+			return nil, err
+		}
+		for _, server := range loaded {
+			if scope != "" && server.Scope != scope {
+				continue
+			}
+			if len(names) > 0 && !containsString(names, server.Name) {
+				continue
+			}
+			found[server.Name] = true
+			servers = append(servers, server)
+		}
+	}
+
+	for _, name := range names {
+		if !found[name] {
+			return nil, notFound("MCP server %q not found", name)
+		}
+	}
+	return servers, nil
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/budget"
+	"claudectl/internal/domain"
+	"claudectl/internal/loaders"
+	"claudectl/internal/mcpclient"
+)
+
+var benchSortKeys = []string{"name", "startup", "cold", "tools", "tokens"}
+
+type MCPBenchOptions struct {
+	Names   []string
+	Scope   domain.CapabilityScope
+	Runs    int
+	Timeout time.Duration
+	Sort    string
+	JSON    bool
+}
+
+func mcpBenchSubcommand() *subcommand {
+	return &subcommand{
+		name:    "bench",
+		usage:   "mcp bench [NAME...] [--scope user|project|local] [--runs N] [--timeout 30s] [--sort name|startup|cold|tools|tokens] [--json]",
+		summary: "Measure server startup time, tool count and tool definition token cost",
+		parse:   parseMCPBench,
+	}
+}
+
+func parseMCPBench(args []string) (fx.Option, error) {
+	var opts MCPBenchOptions
+	var scope string
+
+	fs := newFlagSet("bench")
+	fs.StringVar(&scope, "scope", "", "Only benchmark this scope: user|project|local")
+	fs.IntVar(&opts.Runs, "runs", 3, "Starts per server")
+	fs.DurationVar(&opts.Timeout, "timeout", 30*time.Second, "Timeout for each start")
+	fs.StringVar(&opts.Sort, "sort", "tokens", "Sort by name|startup|cold|tools|tokens")
+	fs.BoolVar(&opts.JSON, "json", false, "Output as JSON")
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Runs < 1 {
+		return nil, fmt.Errorf("--runs must be at least 1")
+	}
+	if !containsString(benchSortKeys, opts.Sort) {
+		return nil, fmt.Errorf("invalid --sort %q (expected name, startup, cold, tools or tokens)", opts.Sort)
+	}
+	if scope != "" {
+		if opts.Scope, err = domain.ParseScope(scope); err != nil {
+			return nil, err
+		}
+	}
+	opts.Names = positional
+
+	return fx.Options(fx.Supply(opts), fx.Invoke(RunMCPBench)), nil
+}
+
+func RunMCPBench(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts MCPBenchOptions,
+	mcpLoader loaders.MCPLoader,
+) {
+	runCommandContext(lc, shutdowner, func(ctx context.Context) error {
+		servers, err := loadMCPServers(mcpLoader, opts.Scope, opts.Names)
+		if err != nil {
+			return err
+		}
+
+		var results []mcpclient.BenchResult
+		for _, server := range servers {
+			if !opts.JSON {
+				fmt.Fprintf(os.Stderr, "Benchmarking %s (%s)...\n", server.Name, server.Scope)
+			}
+			result := mcpclient.Bench(ctx, server, opts.Runs, opts.Timeout, nil)
+			results = append(results, result)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if result.Error == "" {
+				// Later budget runs and the TUI use the tool cost
+				measurement := budget.Measurement{
+					Tools:             result.Tools,
+					ToolTokens:        result.ToolTokens,
+					InstructionTokens: result.InstructionTokens,
+					MeasuredAt:        time.Now(),
+				}
+				if err := budget.Record(server.Scope, server.Name, measurement); err != nil {
+					return err
+				}
+			}
+		}
+		sortBenchResults(results, opts.Sort)
+
+		if opts.JSON {
+			data, err := json.MarshalIndent(results, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+		printBenchTable(results)
+		return nil
+	})
+}
+
+func sortBenchResults(results []mcpclient.BenchResult, by string) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		switch by {
+		case "startup":
+			return a.Median() > b.Median()
+		case "cold":
+			return a.Cold() > b.Cold()
+		case "tools":
+			return a.Tools > b.Tools
+		case "tokens":
+			return a.ToolTokens+a.InstructionTokens > b.ToolTokens+b.InstructionTokens
+		default:
+			return a.Server < b.Server
+		}
+	})
+}
+
+func printBenchTable(results []mcpclient.BenchResult) {
+	if len(results) == 0 {
+		fmt.Println("No MCP servers found")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSCOPE\tTRANSPORT\tCOLD\tMEDIAN\tRUNS\tTOOLS\t~TOKENS\tSTATUS")
+	totalTokens := 0
+	for _, r := range results {
+		status := "ok"
+		if r.Error != "" {
+			status = r.Error
+		}
+		tokens := r.ToolTokens + r.InstructionTokens
+		totalTokens += tokens
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\t%s\n",
+			r.Server, r.Scope, r.Transport, formatDuration(r.Cold()), formatDuration(r.Median()),
+			r.Runs, r.Tools, tokens, status)
+	}
+	w.Flush()
+
+	for _, r := range results {
+		if r.Error != "" {
+			fmt.Println("\nRun `claudectl mcp trace NAME` to see the traffic and stderr of a failed server")
+			break
+		}
+	}
+	fmt.Printf("\nTotal ~%d tokens of tool definitions and instructions\n", totalTokens)
+}
+
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return "-"
+	}
+	return d.Round(time.Millisecond).String()
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/loaders"
+	"claudectl/internal/mcpformats"
+)
+
+type MCPExportOptions struct {
+	Format    mcpformats.Format
+	Scope     domain.CapabilityScope
+	Names     []string
+	Output    string
+	Overwrite bool
+}
+
+func mcpExportSubcommand() *subcommand {
+	return &subcommand{
+		name:    "export",
+		usage:   "mcp export --format claude-desktop|vscode|cursor|codex-toml [--scope user|project|local] [--name NAME]... [--output FILE] [--overwrite]",
+		summary: "Render MCP servers in another client's config format",
+		parse:   parseMCPExport,
+	}
+}
+
+func parseMCPExport(args []string) (fx.Option, error) {
+	var opts MCPExportOptions
+	var format, scope string
+	var names stringList
+
+	fs := newFlagSet("export")
+	fs.StringVar(&format, "format", "", "Target format: claude-desktop|vscode|cursor|codex-toml")
+	fs.StringVar(&scope, "scope", "", "Only export this scope: user|project|local (default: the servers Claude Code would use)")
+	fs.Var(&names, "name", "Only export this server (repeatable)")
+	fs.StringVar(&opts.Output, "output", "", "Write to FILE instead of stdout")
+	fs.BoolVar(&opts.Overwrite, "overwrite", false, "Replace FILE if it exists")
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", positional[0])
+	}
+	if format == "" {
+		return nil, fmt.Errorf("--format is required")
+	}
+	if opts.Format, err = mcpformats.ParseFormat(format, mcpformats.ExportFormats); err != nil {
+		return nil, err
+	}
+	if scope != "" {
+		if opts.Scope, err = domain.ParseScope(scope); err != nil {
+			return nil, err
+		}
+	}
+	opts.Names = names
+
+	return fx.Options(fx.Supply(opts), fx.Invoke(RunMCPExport)), nil
+}
+
+func RunMCPExport(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts MCPExportOptions,
+	mcpLoader loaders.MCPLoader,
+) {
+	runCommand(lc, shutdowner, func() error {
+		servers, err := loadMCPServers(mcpLoader, opts.Scope, opts.Names)
+		if err != nil {
+			return err
+		}
+
+		exported, err := mcpformats.Export(opts.Format, servers)
+		if err != nil {
+			return err
+		}
+		for _, warning := range exported.Warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		}
+
+		if opts.Output == "" {
+			_, err := os.Stdout.Write(exported.Data)
+			return err
+		}
+		if err := exported.WriteFile(opts.Output, opts.Overwrite); err != nil {
+			if !opts.Overwrite {
+				return fmt.Errorf("%w (pass --overwrite to replace it)", err)
+			}
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %d server(s) to %s\n", exported.Servers, opts.Output)
+		return nil
+	})
+}
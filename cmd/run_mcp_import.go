@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/loaders"
+	"claudectl/internal/mcpformats"
+	"claudectl/internal/utils"
+	"claudectl/internal/writers"
+)
+
+type MCPImportOptions struct {
+	From      string
+	Format    string
+	Scope     domain.CapabilityScope
+	Only      []string
+	Overwrite bool
+	DryRun    bool
+	Yes       bool
+}
+
+func mcpImportSubcommand() *subcommand {
+	return &subcommand{
+		name:    "import",
+		usage:   "mcp import --from FILE [--format auto|claude-desktop|vscode|cursor] [--scope user|project|local] [--only NAME]... [--overwrite] [--dry-run] [--yes]",
+		summary: "Import MCP servers from another client's config",
+		parse:   parseMCPImport,
+	}
+}
+
+func parseMCPImport(args []string) (fx.Option, error) {
+	var opts MCPImportOptions
+	var scope string
+	var only stringList
+
+	fs := newFlagSet("import")
+	fs.StringVar(&opts.From, "from", "", "Config file to import from")
+	fs.StringVar(&opts.Format, "format", "auto", "Source format: auto|claude-desktop|vscode|cursor")
+	fs.StringVar(&scope, "scope", string(domain.ScopeLocal), "Target scope: user|project|local")
+	fs.Var(&only, "only", "Only import this server (repeatable)")
+	fs.BoolVar(&opts.Overwrite, "overwrite", false, "Replace servers that already exist in the target scope")
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "Show the preview without writing anything")
+	fs.BoolVar(&opts.Yes, "yes", false, "Write without asking for confirmation")
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", positional[0])
+	}
+	if opts.From == "" {
+		return nil, fmt.Errorf("--from is required")
+	}
+	if opts.Format != "auto" {
+		if _, err := mcpformats.ParseFormat(opts.Format, mcpformats.ImportFormats); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Scope, err = domain.ParseScope(scope); err != nil {
+		return nil, err
+	}
+	opts.Only = only
+
+	return fx.Options(fx.Supply(opts), fx.Invoke(RunMCPImport)), nil
+}
+
+func RunMCPImport(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts MCPImportOptions,
+	mcpLoader loaders.MCPLoader,
+	mcpWriter writers.MCPWriter,
+	logger *utils.Logger,
+) {
+	runCommand(lc, shutdowner, func() error {
+		data, err := os.ReadFile(opts.From)
+		if err != nil {
+			return err
+		}
+
+		format := mcpformats.Format(opts.Format)
+		if opts.Format == "auto" {
+			if format, err = mcpformats.DetectFormat(opts.From, data); err != nil {
+				return err
+			}
+		}
+
+		imported, err := mcpformats.Import(format, data)
+		if err != nil {
+			return fmt.Errorf("failed to read %s config: %w", format, err)
+		}
+
+		existing, err := mcpLoader.Load(opts.Scope)
+		if err != nil {
+			return err
+		}
+		taken := map[string]bool{}
+		for _, server := range existing {
+			if server.Scope == opts.Scope {
+				taken[server.Name] = true
+			}
+		}
+
+		var servers []domain.MCPServer
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintf(w, "Importing from %s (%s) into %s scope:\n\n", opts.From, format, opts.Scope)
+		fmt.Fprintln(w, "NAME\tTYPE\tTARGET\tSTATUS")
+		for _, server := range imported.Servers {
+			if len(opts.Only) > 0 && !containsString(opts.Only, server.Name) {
+				continue
+			}
+
+			status := "new"
+			switch {
+			case taken[server.Name] && opts.Overwrite:
+				status = "overwrite"
+			case taken[server.Name]:
+				status = "skip (exists)"
+			}
+
+			target := server.Url
+			if server.Transport() == domain.TransportStdio {
+				target = strings.TrimSpace(server.Command + " " + strings.Join(server.Args, " "))
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", server.Name, server.Transport(), target, status)
+
+			if status != "skip (exists)" {
+				server.Scope = opts.Scope
+				servers = append(servers, server)
+			}
+		}
+		w.Flush()
+
+		var warnings []string
+		for _, warning := range imported.Warnings {
+			name, _, _ := strings.Cut(warning, ": ")
+			if len(opts.Only) == 0 || containsString(opts.Only, name) {
+				warnings = append(warnings, warning)
+			}
+		}
+		if len(warnings) > 0 {
+			fmt.Println("\nWarnings:")
+			for _, warning := range warnings {
+				fmt.Printf("  %s\n", warning)
+			}
+		}
+
+		if len(servers) == 0 {
+			fmt.Println("\nNothing to import")
+			return nil
+		}
+		if opts.DryRun {
+			fmt.Println("\nDry run, nothing written")
+			return nil
+		}
+		if !opts.Yes && !confirm(fmt.Sprintf("\nImport %d server(s) into %s scope?", len(servers), opts.Scope)) {
+			fmt.Println("Aborted")
+			return nil
+		}
+
+		if err := mcpWriter.Put(opts.Overwrite, servers...); err != nil {
+			return err
+		}
+		logger.Info("imported MCP servers", "count", len(servers), "format", format, "scope", opts.Scope)
+		fmt.Printf("Imported %d server(s) into %s scope\n", len(servers), opts.Scope)
+		return nil
+	})
+}
+
+// confirm asks a yes/no question on stdin, defaulting to no
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/loaders"
+	"claudectl/internal/mcpclient"
+)
+
+type MCPTraceOptions struct {
+	Name    string
+	Scope   domain.CapabilityScope
+	Timeout time.Duration
+	Follow  bool
+	JSON    bool
+}
+
+func mcpTraceSubcommand() *subcommand {
+	return &subcommand{
+		name:    "trace",
+		usage:   "mcp trace NAME [--scope user|project|local] [--timeout 30s] [--follow] [--json]",
+		summary: "Start a server and stream its JSON-RPC traffic and stderr",
+		parse:   parseMCPTrace,
+	}
+}
+
+func parseMCPTrace(args []string) (fx.Option, error) {
+	var opts MCPTraceOptions
+	var scope string
+
+	fs := newFlagSet("trace")
+	fs.StringVar(&scope, "scope", "", "Scope of the server: user|project|local")
+	fs.DurationVar(&opts.Timeout, "timeout", 30*time.Second, "Timeout for the handshake")
+	fs.BoolVar(&opts.Follow, "follow", false, "Keep the server running and stream until interrupted")
+	fs.BoolVar(&opts.JSON, "json", false, "Print events as JSON lines")
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) != 1 {
+		return nil, fmt.Errorf("expected exactly one server name")
+	}
+	opts.Name = positional[0]
+	if scope != "" {
+		if opts.Scope, err = domain.ParseScope(scope); err != nil {
+			return nil, err
+		}
+	}
+
+	return fx.Options(fx.Supply(opts), fx.Invoke(RunMCPTrace)), nil
+}
+
+func RunMCPTrace(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts MCPTraceOptions,
+	mcpLoader loaders.MCPLoader,
+) {
+	runCommandContext(lc, shutdowner, func(ctx context.Context) error {
+		server, err := findMCPServer(mcpLoader, opts.Name, opts.Scope)
+		if err != nil {
+			return err
+		}
+
+		var mu sync.Mutex
+		trace := mcpclient.NewTrace(mcpclient.DefaultTraceSize)
+		unsubscribe := trace.Subscribe(func(event mcpclient.Event) {
+			mu.Lock()
+			defer mu.Unlock()
+			if opts.JSON {
+				data, _ := json.Marshal(event)
+				fmt.Println(string(data))
+				return
+			}
+			fmt.Println(event.Format())
+		})
+		defer unsubscribe()
+
+		handshakeCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+		client, _, err := mcpclient.Inspect(handshakeCtx, *server, trace)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		if opts.Follow {
+			fmt.Fprintln(os.Stderr, "Following, press Ctrl-C to stop")
+			<-ctx.Done()
+		}
+		return nil
+	})
+}
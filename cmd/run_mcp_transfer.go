@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/loaders"
+	"claudectl/internal/utils"
+	"claudectl/internal/writers"
+)
+
+type MCPTransferOptions struct {
+	Move           bool
+	Name           string
+	Scope          domain.CapabilityScope
+	Target         domain.CapabilityScope
+	NewName        string
+	Overwrite      bool
+	ExtractSecrets bool
+}
+
+func mcpTransferSubcommands() []*subcommand {
+	return []*subcommand{
+		{
+			name:    "move",
+			usage:   "mcp move <name> --to user|project|local [--scope FROM] [--as NEW_NAME] [--overwrite] [--extract-secrets|--keep-secrets]",
+			summary: "Move an MCP server to another scope",
+			parse: func(args []string) (fx.Option, error) {
+				return parseMCPTransfer("move", true, args)
+			},
+		},
+		{
+			name:    "copy",
+			usage:   "mcp copy <name> --to user|project|local [--scope FROM] [--as NEW_NAME] [--overwrite] [--extract-secrets|--keep-secrets]",
+			summary: "Copy an MCP server to another scope",
+			parse: func(args []string) (fx.Option, error) {
+				return parseMCPTransfer("copy", false, args)
+			},
+		},
+	}
+}
+
+func parseMCPTransfer(name string, move bool, args []string) (fx.Option, error) {
+	opts := MCPTransferOptions{Move: move}
+	var scope, target string
+	var extract, keep bool
+
+	fs := newFlagSet(name)
+	fs.StringVar(&scope, "scope", "", "Scope the server is currently in")
+	fs.StringVar(&target, "to", "", "Target scope: user|project|local")
+	fs.StringVar(&opts.NewName, "as", "", "Name to use in the target scope")
+	fs.BoolVar(&opts.Overwrite, "overwrite", false, "Replace a server with the same name in the target")
+	fs.BoolVar(&extract, "extract-secrets", false, "Replace hardcoded secrets with ${VAR} references (default for --to project)")
+	fs.BoolVar(&keep, "keep-secrets", false, "Copy hardcoded secrets as they are, even into the project scope")
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) != 1 {
+		return nil, fmt.Errorf("expected exactly one server name, got %d", len(positional))
+	}
+	opts.Name = positional[0]
+	if opts.NewName != "" {
+		if err := domain.ValidateMCPServerName(opts.NewName); err != nil {
+			return nil, err
+		}
+	}
+
+	if target == "" {
+		return nil, fmt.Errorf("--to is required")
+	}
+	if opts.Target, err = domain.ParseScope(target); err != nil {
+		return nil, err
+	}
+	if extract && keep {
+		return nil, fmt.Errorf("--extract-secrets and --keep-secrets cannot be combined")
+	}
+	opts.ExtractSecrets = extract || (!keep && domain.ExtractSecretsByDefault(opts.Target))
+	if scope != "" {
+		if opts.Scope, err = domain.ParseScope(scope); err != nil {
+			return nil, err
+		}
+	}
+
+	return fx.Options(fx.Supply(opts), fx.Invoke(RunMCPTransfer)), nil
+}
+
+func RunMCPTransfer(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts MCPTransferOptions,
+	mcpLoader loaders.MCPLoader,
+	mcpWriter writers.MCPWriter,
+	logger *utils.Logger,
+) {
+	runCommand(lc, shutdowner, func() error {
+		server, err := findMCPServer(mcpLoader, opts.Name, opts.Scope)
+		if err != nil {
+			return err
+		}
+
+		target, secrets := transferTarget(server, opts.Target, opts.NewName, opts.ExtractSecrets)
+
+		verb := "Copied"
+		if opts.Move {
+			verb = "Moved"
+			err = mcpWriter.Move(*server, *target, opts.Overwrite)
+		} else {
+			err = mcpWriter.Copy(*server, *target, opts.Overwrite)
+		}
+		if err != nil {
+			return fmt.Errorf("%w (use --as to pick another name or --overwrite to replace it)", err)
+		}
+
+		logger.Info("transferred MCP server", "name", server.Name, "from", server.Scope, "to", target.Scope, "move", opts.Move)
+		fmt.Printf("%s MCP server %q from %s to %s scope", verb, server.Name, server.Scope, target.Scope)
+		if target.Name != server.Name {
+			fmt.Printf(" as %q", target.Name)
+		}
+		fmt.Println()
+		printSecretReferences(secrets, opts.Move)
+		if !opts.ExtractSecrets && target.Scope == domain.ScopeProject {
+			if kept := len(target.Clone().ReplaceSecrets()); kept > 0 {
+				fmt.Fprintf(os.Stderr, "Warning: %d hardcoded secret(s) were written to the shared project config\n", kept)
+			}
+		}
+		return nil
+	})
+}
+
+// transferTarget builds the server to write into the target scope
+func transferTarget(server *domain.MCPServer, scope domain.CapabilityScope, name string, extractSecrets bool) (*domain.MCPServer, []domain.SecretReference) {
+	target := server.Clone()
+	target.Scope = scope
+	if name != "" {
+		target.Name = name
+	}
+
+	var secrets []domain.SecretReference
+	if extractSecrets {
+		secrets = target.ReplaceSecrets()
+	}
+	return target, secrets
+}
+
+func printSecretReferences(secrets []domain.SecretReference, moved bool) {
+	if len(secrets) == 0 {
+		return
+	}
+
+	fmt.Println("\nHardcoded secrets were replaced with environment references:")
+	for _, secret := range secrets {
+		fmt.Printf("  %-24s ${%s}\n", secret.Field, secret.Variable)
+	}
+
+	if moved {
+		// The original entry is gone, so this is the last chance to see the values
+		fmt.Println("\nSet these variables before starting Claude Code:")
+		for _, secret := range secrets {
+			fmt.Printf("  %s\n", secret.Export())
+		}
+	} else {
+		fmt.Println("\nThe original values are still in the source scope's config.")
+	}
+}
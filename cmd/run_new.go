@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/scaffold"
+)
+
+type NewOptions struct {
+	scaffold.Params
+}
+
+func newSubcommand() *subcommand {
+	cmd := &subcommand{
+		name:    "new",
+		usage:   "new command|skill|agent <name> [flags]",
+		summary: "Create a command, skill or agent from a template",
+	}
+	for _, kind := range scaffold.Kinds {
+		usage := fmt.Sprintf("new %s <name> [--scope user|project] [--description TEXT] [--template-dir DIR]", kind)
+		if kind == domain.TypeCommand {
+			usage = "new command [<namespace>:]<name> [--scope user|project] [--description TEXT] [--template-dir DIR]"
+		}
+		cmd.children = append(cmd.children, &subcommand{
+			name:    string(kind),
+			usage:   usage,
+			summary: fmt.Sprintf("Create a new %s", kind),
+			parse:   parseNew(kind),
+		})
+	}
+	return cmd
+}
+
+func parseNew(kind domain.CapabilityType) func(args []string) (fx.Option, error) {
+	return func(args []string) (fx.Option, error) {
+		opts := NewOptions{scaffold.Params{Kind: kind}}
+		var scope string
+
+		fs := newFlagSet("new " + string(kind))
+		fs.StringVar(&scope, "scope", "project", "Scope: user|project")
+		fs.StringVar(&opts.Description, "description", "", "Description written into the frontmatter")
+		fs.StringVar(&opts.TemplateDir, "template-dir", "", "Directory with command.md, skill.md or agent.md templates")
+		positional, _, err := parseInterspersed(fs, args)
+		if err != nil {
+			return nil, err
+		}
+		if len(positional) != 1 {
+			return nil, fmt.Errorf("expected exactly one name")
+		}
+		opts.Namespace, opts.Name = scaffold.SplitName(positional[0])
+		if opts.Scope, err = domain.ParseScope(scope); err != nil {
+			return nil, err
+		}
+
+		return fx.Options(fx.Supply(opts), fx.Invoke(RunNew)), nil
+	}
+}
+
+func RunNew(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts NewOptions,
+) {
+	runCommand(lc, shutdowner, func() error {
+		path, err := scaffold.Create(opts.Params)
+		if err != nil {
+			return err
+		}
+		fmt.Println(path)
+		return nil
+	})
+}
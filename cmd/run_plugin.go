@@ -0,0 +1,33 @@
+package main
+
+import (
+	"go.uber.org/fx"
+
+	"claudectl/internal/domain"
+)
+
+func pluginSubcommand() *subcommand {
+	return &subcommand{
+		name:    "plugin",
+		usage:   "plugin <command> [flags]",
+		summary: "Inspect installed plugins",
+		children: []*subcommand{
+			{
+				name:    "list",
+				usage:   "plugin list [--scope user|project|all] [--name GLOB|/REGEX/] [--grep TEXT] [--output FORMAT] [--fields a,b,...] [--sort a,b,...] [--template TEXT]",
+				summary: "List installed plugins",
+				parse: func(args []string) (fx.Option, error) {
+					return parseListOptions("list", args, []domain.CapabilityType{domain.TypePlugin})
+				},
+			},
+			{
+				name:    "show",
+				usage:   "plugin show <name> [--scope user|project] [--json|--raw]",
+				summary: "Print the details of a plugin",
+				parse: func(args []string) (fx.Option, error) {
+					return parseShowOptions("show", args, domain.TypePlugin)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/domain"
+)
+
+type RenderOptions struct {
+	Name  string
+	Scope domain.CapabilityScope
+	Args  []string
+}
+
+func renderSubcommand() *subcommand {
+	return &subcommand{
+		name:    "render",
+		usage:   "render command <name> [--scope user|project] [-- args...]",
+		summary: "Print a command's prompt with arguments substituted",
+		children: []*subcommand{
+			{
+				name:    "command",
+				usage:   "render command <name> [--scope user|project] [-- args...]",
+				summary: "Print a command's prompt with arguments substituted",
+				parse:   parseRender,
+			},
+		},
+	}
+}
+
+func parseRender(args []string) (fx.Option, error) {
+	var opts RenderOptions
+	var scope string
+
+	fs := newFlagSet("render")
+	fs.StringVar(&scope, "scope", "", "Scope: user|project (required when the name exists in both)")
+	positional, rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) != 1 {
+		return nil, fmt.Errorf("expected a command name; pass arguments after --")
+	}
+	opts.Name, opts.Args = positional[0], rest
+	if scope != "" {
+		if opts.Scope, err = domain.ParseScope(scope); err != nil {
+			return nil, err
+		}
+	}
+
+	return fx.Options(fx.Supply(opts), fx.Invoke(RunRender)), nil
+}
+
+func RunRender(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts RenderOptions,
+	capabilities CapabilityLoaders,
+) {
+	runCommand(lc, shutdowner, func() error {
+		vm, err := capabilities.Find(domain.TypeCommand, opts.Name, opts.Scope)
+		if err != nil {
+			return err
+		}
+
+		expansion := domain.ExpandArguments(vm.GetContent(), joinArguments(opts.Args))
+		fmt.Print(expansion.String())
+		if !strings.HasSuffix(expansion.String(), "\n") {
+			fmt.Println()
+		}
+
+		for _, n := range expansion.Missing {
+			fmt.Fprintf(os.Stderr, "Warning: $%d has no argument\n", n)
+		}
+		for _, n := range expansion.Unreferenced {
+			fmt.Fprintf(os.Stderr, "Warning: argument %d (%q) is never used\n", n, expansion.Args[n-1])
+		}
+		return nil
+	})
+}
+
+// joinArguments rebuilds the argument string typed after a slash command,
+// quoting words that contain spaces so $1, $2... split the same way.
+// domain.SplitArguments has no escapes, so a word holding both kinds of
+// quote is built from adjacent quoted runs.
+func joinArguments(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		switch {
+		case arg != "" && !strings.ContainsAny(arg, " \t\n\"'"):
+		case !strings.Contains(arg, "'"):
+			arg = "'" + arg + "'"
+		case !strings.Contains(arg, `"`):
+			arg = `"` + arg + `"`
+		default:
+			arg = "'" + strings.ReplaceAll(arg, "'", `'"'"'`) + "'"
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/writers"
+)
+
+type RemoveOptions struct {
+	Type  domain.CapabilityType
+	Names []string
+	Scope domain.CapabilityScope
+}
+
+type RenameOptions struct {
+	Type    domain.CapabilityType
+	Name    string
+	NewName string
+	Scope   domain.CapabilityScope
+}
+
+// Types that rm and mv work on; plugins are managed by Claude Code
+var writableTypes = []domain.CapabilityType{domain.TypeMCP, domain.TypeCommand, domain.TypeSkill, domain.TypeAgent}
+
+func rmSubcommand() *subcommand {
+	return &subcommand{
+		name:    "rm",
+		usage:   "rm <mcp|command|skill|agent> <name>... [--scope user|project|local]",
+		summary: "Move capabilities to the trash",
+		parse:   parseRemove,
+	}
+}
+
+func mvSubcommand() *subcommand {
+	return &subcommand{
+		name:    "mv",
+		usage:   "mv <mcp|command|skill|agent> <name> <new-name> [--scope user|project|local]",
+		summary: "Rename a capability",
+		parse:   parseRename,
+	}
+}
+
+func parseWritableType(s string) (domain.CapabilityType, error) {
+	kind, err := parseCapabilityType(s)
+	if err != nil {
+		return "", err
+	}
+	if !containsString(stringsOf(writableTypes), string(kind)) {
+		return "", fmt.Errorf("%ss are managed by Claude Code and cannot be changed here", kind)
+	}
+	return kind, nil
+}
+
+func parseRemove(args []string) (fx.Option, error) {
+	var opts RemoveOptions
+	var scope string
+
+	fs := newFlagSet("rm")
+	fs.StringVar(&scope, "scope", "", "Scope: user|project|local (required when a name exists in several)")
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) < 2 {
+		return nil, fmt.Errorf("expected a type and at least one name")
+	}
+	if opts.Type, err = parseWritableType(positional[0]); err != nil {
+		return nil, err
+	}
+	opts.Names = positional[1:]
+	if scope != "" {
+		if opts.Scope, err = domain.ParseScope(scope); err != nil {
+			return nil, err
+		}
+	}
+
+	return fx.Options(fx.Supply(opts), fx.Invoke(RunRemove)), nil
+}
+
+func parseRename(args []string) (fx.Option, error) {
+	var opts RenameOptions
+	var scope string
+
+	fs := newFlagSet("mv")
+	fs.StringVar(&scope, "scope", "", "Scope: user|project|local (required when the name exists in several)")
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) != 3 {
+		return nil, fmt.Errorf("expected a type, a name and a new name")
+	}
+	if opts.Type, err = parseWritableType(positional[0]); err != nil {
+		return nil, err
+	}
+	opts.Name, opts.NewName = positional[1], positional[2]
+	if scope != "" {
+		if opts.Scope, err = domain.ParseScope(scope); err != nil {
+			return nil, err
+		}
+	}
+
+	return fx.Options(fx.Supply(opts), fx.Invoke(RunRename)), nil
+}
+
+func RunRemove(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts RemoveOptions,
+	capabilities CapabilityLoaders,
+	writer writers.CapabilityWriter,
+) {
+	runCommand(lc, shutdowner, func() error {
+		// Resolve every name first so a typo doesn't leave a partial removal
+		var targets []string
+		for _, name := range opts.Names {
+			if _, err := capabilities.Find(opts.Type, name, opts.Scope); err != nil {
+				return err
+			}
+			targets = append(targets, name)
+		}
+
+		for _, name := range targets {
+			// Look up again: removing an MCP server changes the file the
+			// next one was loaded from
+			vm, err := capabilities.Find(opts.Type, name, opts.Scope)
+			if err != nil {
+				return err
+			}
+			entry, err := writer.Remove(vm)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Moved %s %q (%s) to the trash as %s\n", entry.Type, entry.Name, entry.Scope, entry.ID)
+		}
+		fmt.Println("Undo with: claudectl trash restore <id>")
+		return nil
+	})
+}
+
+func RunRename(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts RenameOptions,
+	capabilities CapabilityLoaders,
+	writer writers.CapabilityWriter,
+) {
+	runCommand(lc, shutdowner, func() error {
+		vm, err := capabilities.Find(opts.Type, opts.Name, opts.Scope)
+		if err != nil {
+			return err
+		}
+		name, err := writer.Rename(vm, opts.NewName)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Renamed %s %q to %q (%s)\n", opts.Type, vm.FilterValue(), name, vm.GetScope())
+		return nil
+	})
+}
+
+func stringsOf[T ~string](values []T) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = string(v)
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/output"
+)
+
+func schemaSubcommand() *subcommand {
+	return &subcommand{
+		name:    "schema",
+		usage:   "schema",
+		summary: "Print the JSON Schema of the records list and show print as JSON",
+		parse:   parseSchema,
+	}
+}
+
+func parseSchema(args []string) (fx.Option, error) {
+	fs := newFlagSet("schema")
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", positional[0])
+	}
+	return fx.Invoke(RunSchema), nil
+}
+
+func RunSchema(lc fx.Lifecycle, shutdowner fx.Shutdowner) {
+	runCommand(lc, shutdowner, func() error {
+		_, err := os.Stdout.Write(output.Schema)
+		return err
+	})
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"go.uber.org/fx"
+
+	"claudectl/internal/budget"
+	"claudectl/internal/domain"
+	"claudectl/internal/search"
+	"claudectl/internal/viewmodels"
+)
+
+type SearchOptions struct {
+	Query string
+	// Types are capability types and search.KindMemory
+	Types []string
+	Scope domain.CapabilityScope
+	Limit int
+	JSON  bool
+}
+
+func searchSubcommand() *subcommand {
+	return &subcommand{
+		name:    "search",
+		usage:   "search <query> [--type mcp|command|skill|agent|plugin|memory]... [--scope user|project|local|all] [--limit n] [--json]",
+		summary: "Rank capabilities, memory files and plugin manifests by how well they match the query",
+		parse:   parseSearch,
+	}
+}
+
+func parseSearch(args []string) (fx.Option, error) {
+	var opts SearchOptions
+	var scope string
+	var types stringList
+
+	fs := newFlagSet("search")
+	fs.Var(&types, "type", "Only search this type (repeatable)")
+	fs.StringVar(&scope, "scope", "all", "Scope: user|project|local|all")
+	fs.IntVar(&opts.Limit, "limit", 20, "Show at most this many results, 0 for all")
+	fs.BoolVar(&opts.JSON, "json", false, "Output as JSON")
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) == 0 {
+		return nil, fmt.Errorf("expected a query")
+	}
+	if opts.Limit < 0 {
+		return nil, fmt.Errorf("--limit must not be negative")
+	}
+	opts.Query = strings.Join(positional, " ")
+	for _, t := range types {
+		kind := search.KindMemory
+		if t != search.KindMemory {
+			capType, err := parseCapabilityType(t)
+			if err != nil {
+				return nil, err
+			}
+			kind = string(capType)
+		}
+		if !containsString(opts.Types, kind) {
+			opts.Types = append(opts.Types, kind)
+		}
+	}
+	if len(opts.Types) == 0 {
+		for _, kind := range capabilityTypes {
+			opts.Types = append(opts.Types, string(kind))
+		}
+		opts.Types = append(opts.Types, search.KindMemory)
+	}
+	if opts.Scope, err = parseScopeFilter(scope); err != nil {
+		return nil, err
+	}
+
+	return fx.Options(fx.Supply(opts), fx.Invoke(RunSearch)), nil
+}
+
+// searchResult is a ranked match with the line that best matches
+type searchResult struct {
+	Name    string                 `json:"name"`
+	Kind    string                 `json:"kind"`
+	Scope   domain.CapabilityScope `json:"scope"`
+	Plugin  string                 `json:"plugin,omitempty"`
+	Path    string                 `json:"path,omitempty"`
+	Line    int                    `json:"line,omitempty"`
+	Score   float64                `json:"score"`
+	Snippet string                 `json:"snippet,omitempty"`
+}
+
+// searchMatchStyle highlights matched words; lipgloss drops the styling
+// when stdout is not a terminal
+var searchMatchStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("3"))
+
+func highlightMatch(s string) string {
+	return searchMatchStyle.Render(s)
+}
+
+func RunSearch(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts SearchOptions,
+	capabilities CapabilityLoaders,
+) {
+	runCommand(lc, shutdowner, func() error {
+		docs, err := searchDocuments(capabilities, opts)
+		if err != nil {
+			return err
+		}
+		results := search.NewIndex(docs).Search(opts.Query, opts.Limit)
+
+		if opts.JSON {
+			out := []searchResult{}
+			for _, r := range results {
+				out = append(out, searchResult{
+					Name:    r.Document.Name,
+					Kind:    r.Document.Kind,
+					Scope:   r.Document.Scope,
+					Plugin:  r.Document.Plugin,
+					Path:    r.Document.Path,
+					Line:    r.Snippet.Line,
+					Score:   r.Score,
+					Snippet: r.Snippet.Text,
+				})
+			}
+			if err := printJSON(out); err != nil {
+				return err
+			}
+		}
+		if len(results) == 0 {
+			fmt.Fprintf(os.Stderr, "Nothing matches %q\n", opts.Query)
+			return errNothingFound
+		}
+		if opts.JSON {
+			return nil
+		}
+
+		for i, r := range results {
+			if i > 0 {
+				fmt.Println()
+			}
+			origin := []string{r.Document.Kind, string(r.Document.Scope)}
+			if r.Document.Plugin != "" {
+				origin = append(origin, "plugin "+r.Document.Plugin)
+			}
+			fmt.Printf("%s (%s)\n", r.Document.Name, strings.Join(origin, ", "))
+			snippet := r.Snippet.Highlight(highlightMatch)
+			if location := r.Location(); location != "" {
+				snippet = location + ": " + snippet
+			}
+			fmt.Printf("  %s\n", snippet)
+		}
+		return nil
+	})
+}
+
+// searchDocuments loads everything of the searched types and scope,
+// including what plugins contribute
+func searchDocuments(capabilities CapabilityLoaders, opts SearchOptions) ([]search.Document, error) {
+	var items []viewmodels.CapabilityViewModel
+	var plugins []domain.Plugin
+	var memory []budget.MemoryFile
+	for _, kind := range opts.Types {
+		if kind == search.KindMemory {
+			files, err := budget.LoadMemoryFiles()
+			if err != nil {
+				return nil, err
+			}
+			memory = files
+			continue
+		}
+		loaded, err := capabilities.Load(domain.CapabilityType(kind), opts.Scope)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, loaded...)
+	}
+
+	// Plugins contribute commands, skills, agents and MCP servers
+	values, err := capabilities.LoadDomain(domain.TypePlugin, opts.Scope)
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range values {
+		plugins = append(plugins, value.(domain.Plugin))
+	}
+
+	var docs []search.Document
+	for _, doc := range search.Documents(items, plugins, memory) {
+		if containsString(opts.Types, doc.Kind) && (opts.Scope == "" || doc.Scope == opts.Scope) {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/jsonedit"
+	"claudectl/internal/loaders"
+	"claudectl/internal/viewmodels"
+)
+
+type ShowOptions struct {
+	Type  domain.CapabilityType
+	Name  string
+	Scope domain.CapabilityScope
+	JSON  bool
+	Raw   bool
+}
+
+func showSubcommand() *subcommand {
+	return &subcommand{
+		name:    "show",
+		usage:   "show <mcp|command|skill|agent|plugin> <name> [--scope user|project|local] [--json|--raw]",
+		summary: "Print the details of one capability",
+		parse:   parseShow,
+	}
+}
+
+func parseShow(args []string) (fx.Option, error) {
+	return parseShowOptions("show", args, "")
+}
+
+// parseShowOptions parses the show flags. A non-empty kind fixes the type,
+// for commands such as "plugin show".
+func parseShowOptions(name string, args []string, kind domain.CapabilityType) (fx.Option, error) {
+	var opts ShowOptions
+	var scope string
+
+	fs := newFlagSet(name)
+	fs.StringVar(&scope, "scope", "", "Scope: user|project|local (required when the name exists in several)")
+	fs.BoolVar(&opts.JSON, "json", false, "Output as JSON, in the schema printed by \"claudectl schema\"")
+	fs.BoolVar(&opts.Raw, "raw", false, "Print the file the capability was loaded from")
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if opts.JSON && opts.Raw {
+		return nil, fmt.Errorf("--json and --raw cannot be combined")
+	}
+	if kind != "" {
+		positional = append([]string{string(kind)}, positional...)
+	}
+	if len(positional) != 2 {
+		if kind != "" {
+			return nil, fmt.Errorf("expected a name")
+		}
+		return nil, fmt.Errorf("expected a type and a name")
+	}
+	if opts.Type, err = parseCapabilityType(positional[0]); err != nil {
+		return nil, err
+	}
+	opts.Name = positional[1]
+	if scope != "" {
+		if opts.Scope, err = domain.ParseScope(scope); err != nil {
+			return nil, err
+		}
+	}
+
+	return fx.Options(fx.Supply(opts), fx.Invoke(RunShow)), nil
+}
+
+func RunShow(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts ShowOptions,
+	capabilities CapabilityLoaders,
+) {
+	runCommand(lc, shutdowner, func() error {
+		value, vm, err := capabilities.FindDomain(opts.Type, opts.Name, opts.Scope)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case opts.JSON:
+			record, err := newRecord(value, vm)
+			if err != nil {
+				return err
+			}
+			return printJSON(record)
+		case opts.Raw:
+			source, err := rawSource(vm)
+			if err != nil {
+				return err
+			}
+			os.Stdout.Write(source)
+			if !bytes.HasSuffix(source, []byte("\n")) {
+				fmt.Println()
+			}
+		default:
+			fmt.Print(formatDetails(vm))
+		}
+		return nil
+	})
+}
+
+// rawSource returns what a capability was loaded from: its markdown file,
+// a plugin's manifest, or an MCP server's entry in its config file
+func rawSource(vm viewmodels.CapabilityViewModel) ([]byte, error) {
+	switch v := vm.(type) {
+	case *viewmodels.MCPServerViewModel:
+		server := v.Server()
+		data, err := os.ReadFile(server.FilePath)
+		if err != nil {
+			return nil, err
+		}
+		_, serversPath, err := loaders.MCPConfigLocation(server.Scope)
+		if err != nil {
+			return nil, err
+		}
+		entry, err := jsonedit.Find(data, append(serversPath, server.Name)...)
+		if err != nil {
+			return nil, err
+		}
+		return fmt.Appendf(nil, "%s: %s\n", strconv.Quote(server.Name), dedent(entry)), nil
+	case *viewmodels.PluginViewModel:
+		source, err := os.ReadFile(loaders.PluginManifestPath(v.GetFilePath()))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("plugin %q has no manifest", v.GetName())
+		}
+		return source, err
+	default:
+		if vm.GetFilePath() == "" {
+			return nil, fmt.Errorf("%s %q has no source file", vm.GetType(), vm.FilterValue())
+		}
+		return os.ReadFile(vm.GetFilePath())
+	}
+}
+
+// formatDetails renders the sections of the TUI detail panel as plain text
+func formatDetails(vm viewmodels.CapabilityViewModel) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%s, %s scope)\n", vm.FilterValue(), vm.GetType(), vm.GetScope())
+
+	section := func(title string, lines ...string) {
+		fmt.Fprintf(&b, "\n%s\n", title)
+		for _, line := range lines {
+			fmt.Fprintf(&b, "  %s\n", line)
+		}
+	}
+	if path := vm.GetFilePath(); path != "" {
+		section("Location", path)
+	}
+	if description := vm.GetDescription(); description != "" {
+		section("Description", description)
+	}
+	if details := vm.RenderDetails(); len(details) > 0 {
+		section("Details", details...)
+	}
+	if wp, ok := vm.(viewmodels.WarningProvider); ok {
+		if warnings := wp.Warnings(); len(warnings) > 0 {
+			section("Warnings", warnings...)
+		}
+	}
+	if content := vm.GetContent(); content != "" {
+		fmt.Fprintf(&b, "\n%s\n\n%s", strings.Repeat("─", 40), content)
+		if !strings.HasSuffix(content, "\n") {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// dedent removes the indentation that a nested JSON value has in its file,
+// taken from the line holding its closing bracket
+func dedent(value []byte) []byte {
+	lines := bytes.Split(value, []byte("\n"))
+	last := lines[len(lines)-1]
+	indent := last[:len(last)-len(bytes.TrimLeft(last, " \t"))]
+	for i := 1; i < len(lines); i++ {
+		lines[i] = bytes.TrimPrefix(lines[i], indent)
+	}
+	return bytes.Join(lines, []byte("\n"))
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"go.uber.org/fx"
+
+	"claudectl/internal/writers"
+)
+
+type TrashListOptions struct {
+	JSON bool
+}
+
+type TrashRestoreOptions struct {
+	IDs []string
+}
+
+func trashSubcommand() *subcommand {
+	return &subcommand{
+		name:    "trash",
+		usage:   "trash <command> [flags]",
+		summary: "List and restore deleted capabilities",
+		children: []*subcommand{
+			{
+				name:    "list",
+				usage:   "trash list [--json]",
+				summary: "Show deleted capabilities, newest first",
+				parse:   parseTrashList,
+			},
+			{
+				name:    "restore",
+				usage:   "trash restore <id|name>...",
+				summary: "Put deleted capabilities back where they were",
+				parse:   parseTrashRestore,
+			},
+		},
+	}
+}
+
+func parseTrashList(args []string) (fx.Option, error) {
+	var opts TrashListOptions
+
+	fs := newFlagSet("list")
+	fs.BoolVar(&opts.JSON, "json", false, "Output as JSON")
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", positional[0])
+	}
+
+	return fx.Options(fx.Supply(opts), fx.Invoke(RunTrashList)), nil
+}
+
+func parseTrashRestore(args []string) (fx.Option, error) {
+	fs := newFlagSet("restore")
+	positional, _, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) == 0 {
+		return nil, fmt.Errorf("expected at least one trash ID or name")
+	}
+
+	return fx.Options(fx.Supply(TrashRestoreOptions{IDs: positional}), fx.Invoke(RunTrashRestore)), nil
+}
+
+func RunTrashList(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts TrashListOptions,
+	writer writers.CapabilityWriter,
+) {
+	runCommand(lc, shutdowner, func() error {
+		bin, err := writer.Trash()
+		if err != nil {
+			return err
+		}
+		entries, err := bin.List()
+		if err != nil {
+			return err
+		}
+
+		if opts.JSON {
+			data, err := json.MarshalIndent(entries, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if len(entries) == 0 {
+			fmt.Println("The trash is empty")
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTYPE\tNAME\tSCOPE\tDELETED\tPATH")
+		for _, entry := range entries {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", entry.ID, entry.Type, entry.Name, entry.Scope,
+				entry.DeletedAt.Local().Format("2006-01-02 15:04"), entry.Path)
+		}
+		return w.Flush()
+	})
+}
+
+func RunTrashRestore(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	opts TrashRestoreOptions,
+	writer writers.CapabilityWriter,
+) {
+	runCommand(lc, shutdowner, func() error {
+		for _, id := range opts.IDs {
+			entry, err := writer.Restore(id)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Restored %s %q (%s) to %s\n", entry.Type, entry.Name, entry.Scope, entry.Path)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,177 @@
+// Package budget estimates how much of a session's context each capability
+// takes up before any work starts. Memory files are loaded whole, while
+// commands, skills and agents only list their name and description until
+// they are used. MCP servers add their tool definitions, which are only
+// known once a server has been started and measured.
+package budget
+
+import (
+	"sort"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/settings"
+	"claudectl/internal/tokens"
+	"claudectl/internal/viewmodels"
+)
+
+// KindMemory is the Item kind of CLAUDE.md memory files
+const KindMemory = "memory"
+
+type Loading string
+
+const (
+	// LoadingAlways is content that is in the context of every session
+	LoadingAlways Loading = "always"
+	// LoadingMetadata is a name and description listed so Claude can load
+	// the rest on demand
+	LoadingMetadata Loading = "metadata"
+	// LoadingTools is MCP tool definitions and server instructions
+	LoadingTools Loading = "tools"
+)
+
+// Item is the estimated context cost of one capability or memory file
+type Item struct {
+	Kind    string                 `json:"kind"`
+	Name    string                 `json:"name"`
+	Scope   domain.CapabilityScope `json:"scope"`
+	Loading Loading                `json:"loading"`
+	Tokens  int                    `json:"tokens"`
+	// Measured is false for MCP servers whose tools have never been listed
+	Measured bool   `json:"measured"`
+	Path     string `json:"path,omitempty"`
+	// Plugin is the plugin that ships the capability, if any
+	Plugin string `json:"plugin,omitempty"`
+}
+
+// Label is the item's name, followed by the plugin that ships it
+func (i Item) Label() string {
+	if i.Plugin == "" {
+		return i.Name
+	}
+	return i.Name + " (plugin " + i.Plugin + ")"
+}
+
+type Report struct {
+	// Items are sorted by cost, highest first
+	Items      []Item                         `json:"items"`
+	Total      int                            `json:"total"`
+	Scopes     map[domain.CapabilityScope]int `json:"scopes"`
+	Unmeasured int                            `json:"unmeasured"`
+}
+
+// New estimates the cost of memory files and capabilities. Each plugin
+// that settings leave enabled is counted as the commands, skills, agents
+// and MCP servers it ships.
+func New(memory []MemoryFile, capabilities []viewmodels.CapabilityViewModel, s *settings.Settings, measurements Measurements) *Report {
+	report := &Report{Items: []Item{}, Scopes: map[domain.CapabilityScope]int{}}
+
+	for _, file := range memory {
+		report.add(Item{
+			Kind:     KindMemory,
+			Name:     file.Name(),
+			Scope:    file.Scope,
+			Loading:  LoadingAlways,
+			Tokens:   tokens.Estimate(file.Content),
+			Measured: true,
+			Path:     file.Path,
+		})
+	}
+
+	for _, vm := range capabilities {
+		plugin, ok := vm.(*viewmodels.PluginViewModel)
+		if !ok {
+			report.add(capabilityItem(vm, measurements))
+			continue
+		}
+		if plugin.Plugin() != nil && s.PluginEnabled(plugin.Key()) {
+			for _, item := range PluginItems(plugin.Plugin(), measurements) {
+				report.add(item)
+			}
+		}
+	}
+
+	sort.SliceStable(report.Items, func(i, j int) bool {
+		return report.Items[i].Tokens > report.Items[j].Tokens
+	})
+	return report
+}
+
+// PluginItems estimates the cost of what a plugin ships. Its MCP servers
+// are measured under the names Claude Code gives them.
+func PluginItems(plugin *domain.Plugin, measurements Measurements) []Item {
+	var contents []any
+	for _, server := range plugin.MCPServers {
+		contents = append(contents, *plugin.LaunchableServer(server))
+	}
+	for _, command := range plugin.Commands {
+		contents = append(contents, command)
+	}
+	for _, skill := range plugin.Skills {
+		contents = append(contents, skill)
+	}
+	for _, agent := range plugin.Agents {
+		contents = append(contents, agent)
+	}
+
+	var items []Item
+	for _, value := range contents {
+		vm, err := viewmodels.ToDomainViewModel(value)
+		if err != nil {
+			continue
+		}
+		item := capabilityItem(vm, measurements)
+		item.Plugin = plugin.Name
+		items = append(items, item)
+	}
+	return items
+}
+
+func capabilityItem(vm viewmodels.CapabilityViewModel, measurements Measurements) Item {
+	cost, measured := CapabilityTokens(vm, measurements)
+	loading := LoadingMetadata
+	if vm.GetType() == domain.TypeMCP {
+		loading = LoadingTools
+	}
+	return Item{
+		Kind:     string(vm.GetType()),
+		Name:     vm.FilterValue(),
+		Scope:    vm.GetScope(),
+		Loading:  loading,
+		Tokens:   cost,
+		Measured: measured,
+		Path:     vm.GetFilePath(),
+	}
+}
+
+func (r *Report) add(item Item) {
+	r.Items = append(r.Items, item)
+	r.Total += item.Tokens
+	r.Scopes[item.Scope] += item.Tokens
+	if !item.Measured {
+		r.Unmeasured++
+	}
+}
+
+// CapabilityTokens estimates what one capability adds to every session; a
+// plugin adds what it ships. It reports false for MCP servers, or plugins
+// with MCP servers, that have not been measured.
+func CapabilityTokens(vm viewmodels.CapabilityViewModel, measurements Measurements) (int, bool) {
+	switch vm.GetType() {
+	case domain.TypeMCP:
+		m, ok := measurements.Get(vm.GetScope(), vm.GetName())
+		return m.Tokens(), ok
+	case domain.TypePlugin:
+		plugin, ok := vm.(*viewmodels.PluginViewModel)
+		if !ok || plugin.Plugin() == nil {
+			return 0, true
+		}
+		total, measured := 0, true
+		for _, item := range PluginItems(plugin.Plugin(), measurements) {
+			total += item.Tokens
+			measured = measured && item.Measured
+		}
+		return total, measured
+	default:
+		return tokens.Estimate(vm.FilterValue() + ": " + vm.GetDescription()), true
+	}
+}
@@ -0,0 +1,107 @@
+package budget
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/mcpclient"
+	"claudectl/internal/tokens"
+	"claudectl/internal/utils"
+)
+
+// Measurement is the context cost of an MCP server's tools, recorded the
+// last time the server was started by claudectl
+type Measurement struct {
+	Tools             int       `json:"tools"`
+	ToolTokens        int       `json:"toolTokens"`
+	InstructionTokens int       `json:"instructionTokens"`
+	MeasuredAt        time.Time `json:"measuredAt"`
+}
+
+func (m Measurement) Tokens() int {
+	return m.ToolTokens + m.InstructionTokens
+}
+
+// FromInspection measures the tools and instructions of an inspected server
+func FromInspection(server string, inspection *mcpclient.Inspection) Measurement {
+	m := Measurement{
+		Tools:      len(inspection.Tools),
+		ToolTokens: mcpclient.ToolTokens(server, inspection.Tools),
+		MeasuredAt: time.Now(),
+	}
+	if inspection.Info != nil {
+		m.InstructionTokens = tokens.Estimate(inspection.Info.Instructions)
+	}
+	return m
+}
+
+// Measurements are keyed by scope and server name
+type Measurements map[string]Measurement
+
+func measurementKey(scope domain.CapabilityScope, name string) string {
+	return string(scope) + "/" + name
+}
+
+func (ms Measurements) Get(scope domain.CapabilityScope, name string) (Measurement, bool) {
+	m, ok := ms[measurementKey(scope, name)]
+	return m, ok
+}
+
+func measurementsFile() (string, error) {
+	dir, err := utils.GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "mcp-measurements.json"), nil
+}
+
+// LoadMeasurements reads the cached measurements; none is not an error
+func LoadMeasurements() (Measurements, error) {
+	path, err := measurementsFile()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Measurements{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	ms := Measurements{}
+	if err := json.Unmarshal(data, &ms); err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
+
+func (ms Measurements) Set(scope domain.CapabilityScope, name string, m Measurement) {
+	ms[measurementKey(scope, name)] = m
+}
+
+// Record caches the measurement of one server
+func Record(scope domain.CapabilityScope, name string, m Measurement) error {
+	ms, err := LoadMeasurements()
+	if err != nil {
+		// A corrupt cache is replaced
+		ms = Measurements{}
+	}
+	ms.Set(scope, name, m)
+
+	path, err := measurementsFile()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(ms, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,113 @@
+package budget
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/utils"
+)
+
+// maxImportDepth is how deep Claude Code follows @imports in memory files
+const maxImportDepth = 5
+
+// MemoryFile is a CLAUDE.md file with its @imports inlined
+type MemoryFile struct {
+	Path    string
+	Scope   domain.CapabilityScope
+	Content string
+}
+
+// Name is the path relative to the working directory, or to ~ for files
+// outside it
+func (f MemoryFile) Name() string {
+	if cwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(cwd, f.Path); err == nil && filepath.IsLocal(rel) {
+			return rel
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if rel, err := filepath.Rel(home, f.Path); err == nil && filepath.IsLocal(rel) {
+			return filepath.Join("~", rel)
+		}
+	}
+	return f.Path
+}
+
+// LoadMemoryFiles reads the memory files Claude Code loads at startup:
+// ~/.claude/CLAUDE.md, then CLAUDE.md, .claude/CLAUDE.md and
+// CLAUDE.local.md in the working directory and each of its parents
+func LoadMemoryFiles() ([]MemoryFile, error) {
+	var files []MemoryFile
+
+	userDir, err := utils.GetUserClaudeDir()
+	if err != nil {
+		return nil, err
+	}
+	files = appendMemoryFile(files, filepath.Join(userDir, "CLAUDE.md"), domain.ScopeUser)
+
+	dir, err := utils.GetProjectRoot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		files = appendMemoryFile(files, filepath.Join(dir, "CLAUDE.md"), domain.ScopeProject)
+		files = appendMemoryFile(files, filepath.Join(dir, ".claude", "CLAUDE.md"), domain.ScopeProject)
+		files = appendMemoryFile(files, filepath.Join(dir, "CLAUDE.local.md"), domain.ScopeLocal)
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return files, nil
+}
+
+func appendMemoryFile(files []MemoryFile, path string, scope domain.CapabilityScope) []MemoryFile {
+	for _, file := range files {
+		if file.Path == path {
+			return files
+		}
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return files
+	}
+	text := string(content) + imports(filepath.Dir(path), string(content), 1, map[string]bool{path: true})
+	return append(files, MemoryFile{Path: path, Scope: scope, Content: text})
+}
+
+// imports returns the contents of the files content imports with @path,
+// resolved relative to dir
+func imports(dir, content string, depth int, seen map[string]bool) string {
+	if depth > maxImportDepth {
+		return ""
+	}
+	var text string
+	for _, ref := range domain.ExtractFileReferences(content) {
+		if ref.Dynamic() {
+			continue
+		}
+		path := ref.Path
+		if rest, ok := strings.CutPrefix(path, "~/"); ok {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				continue
+			}
+			path = filepath.Join(home, rest)
+		} else if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		text += "\n" + string(data) + imports(filepath.Dir(path), string(data), depth+1, seen)
+	}
+	return text
+}
@@ -4,6 +4,8 @@ type Agent struct {
 	Capability
 	FilePath string
 	Content  string
+	// Tools is the agent's tools list; nil means it inherits every tool
+	Tools []string
 }
 
 type AgentParams struct {
@@ -12,6 +14,7 @@ type AgentParams struct {
 	FilePath    string
 	Description string
 	Content     string
+	Tools       []string
 }
 
 func NewAgent(params AgentParams) *Agent {
@@ -24,5 +27,11 @@ func NewAgent(params AgentParams) *Agent {
 		},
 		FilePath: params.FilePath,
 		Content:  params.Content,
+		Tools:    params.Tools,
 	}
 }
+
+// InheritsTools reports whether the agent has no tools restriction
+func (a *Agent) InheritsTools() bool {
+	return len(a.Tools) == 0
+}
@@ -1,11 +1,15 @@
 package domain
 
+import "fmt"
+
 type CapabilityScope string
 type CapabilityType string
 
 const (
 	ScopeUser    CapabilityScope = "user"
 	ScopeProject CapabilityScope = "project"
+	// ScopeLocal is private to one project and stored in ~/.claude.json
+	ScopeLocal CapabilityScope = "local"
 )
 
 const (
@@ -22,3 +26,13 @@ type Capability struct {
 	Type        CapabilityType
 	Scope       CapabilityScope
 }
+
+// ParseScope validates a scope name given by the user
+func ParseScope(s string) (CapabilityScope, error) {
+	switch scope := CapabilityScope(s); scope {
+	case ScopeUser, ScopeProject, ScopeLocal:
+		return scope, nil
+	default:
+		return "", fmt.Errorf("invalid scope %q (expected user, project or local)", s)
+	}
+}
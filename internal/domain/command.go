@@ -2,16 +2,30 @@ package domain
 
 type Command struct {
 	Capability
-	FilePath string
-	Content  string
+	// Namespace is the subdirectory of commands/ the file lives in, if any
+	Namespace string
+	FilePath  string
+	Content   string
+	// ArgumentHint is the argument-hint shown when typing the command
+	ArgumentHint string
+	AllowedTools []string
+	// FileReferences and ShellSnippets are what the command reads and
+	// runs when it is invoked
+	FileReferences []FileReference
+	ShellSnippets  []ShellSnippet
 }
 
 type CommandParams struct {
-	Name        string
-	Description string
-	Scope       CapabilityScope
-	FilePath    string
-	Content     string
+	Name           string
+	Description    string
+	Scope          CapabilityScope
+	Namespace      string
+	FilePath       string
+	Content        string
+	ArgumentHint   string
+	AllowedTools   []string
+	FileReferences []FileReference
+	ShellSnippets  []ShellSnippet
 }
 
 func NewCommand(params CommandParams) *Command {
@@ -22,7 +36,20 @@ func NewCommand(params CommandParams) *Command {
 			Type:        TypeCommand,
 			Scope:       params.Scope,
 		},
-		FilePath: params.FilePath,
-		Content:  params.Content,
+		Namespace:      params.Namespace,
+		FilePath:       params.FilePath,
+		Content:        params.Content,
+		ArgumentHint:   params.ArgumentHint,
+		AllowedTools:   params.AllowedTools,
+		FileReferences: params.FileReferences,
+		ShellSnippets:  params.ShellSnippets,
 	}
 }
+
+// QualifiedName is the name with its namespace, e.g. "frontend:component"
+func (c *Command) QualifiedName() string {
+	if c.Namespace == "" {
+		return c.Name
+	}
+	return c.Namespace + ":" + c.Name
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ArgumentPartKind says where a piece of an expanded command body came from
+type ArgumentPartKind int
+
+const (
+	PartText ArgumentPartKind = iota
+	// PartArgument is text substituted for $ARGUMENTS or $N
+	PartArgument
+	// PartMissing is a $N placeholder with no argument, left empty
+	PartMissing
+	// PartAppended is the arguments line Claude Code adds to commands
+	// that use no placeholders
+	PartAppended
+)
+
+type ArgumentPart struct {
+	Text string
+	Kind ArgumentPartKind
+	// Placeholder is the text that was replaced, e.g. "$2"
+	Placeholder string
+}
+
+// Expansion is a command body with sample arguments substituted
+type Expansion struct {
+	Parts []ArgumentPart
+	Args  []string
+	// Missing lists the N of $N placeholders that got no argument
+	Missing []int
+	// Unreferenced lists the 1-based positions of arguments the body
+	// never uses
+	Unreferenced []int
+}
+
+func (e *Expansion) String() string {
+	var b strings.Builder
+	for _, part := range e.Parts {
+		b.WriteString(part.Text)
+	}
+	return b.String()
+}
+
+var argumentPattern = regexp.MustCompile(`\$(ARGUMENTS|[0-9]+)`)
+
+// ExpandArguments substitutes args into a command body the way Claude Code
+// does: $ARGUMENTS gets the whole string and $1, $2... the words of it.
+// A body with no placeholders gets the arguments appended instead.
+func ExpandArguments(body, args string) *Expansion {
+	e := &Expansion{Args: SplitArguments(args)}
+	referenced := map[int]bool{}
+	usesAll := false
+	missing := map[int]bool{}
+
+	last := 0
+	for _, m := range argumentPattern.FindAllStringSubmatchIndex(body, -1) {
+		if m[0] > last {
+			e.Parts = append(e.Parts, ArgumentPart{Text: body[last:m[0]]})
+		}
+		last = m[1]
+		placeholder := body[m[0]:m[1]]
+
+		if name := body[m[2]:m[3]]; name == "ARGUMENTS" {
+			usesAll = true
+			e.Parts = append(e.Parts, ArgumentPart{Text: args, Kind: PartArgument, Placeholder: placeholder})
+			continue
+		}
+		n, _ := strconv.Atoi(body[m[2]:m[3]])
+		if n >= 1 && n <= len(e.Args) {
+			referenced[n] = true
+			e.Parts = append(e.Parts, ArgumentPart{Text: e.Args[n-1], Kind: PartArgument, Placeholder: placeholder})
+			continue
+		}
+		if n >= 1 && !missing[n] {
+			missing[n] = true
+			e.Missing = append(e.Missing, n)
+		}
+		e.Parts = append(e.Parts, ArgumentPart{Kind: PartMissing, Placeholder: placeholder})
+	}
+	if last < len(body) {
+		e.Parts = append(e.Parts, ArgumentPart{Text: body[last:]})
+	}
+	sort.Ints(e.Missing)
+
+	if !usesAll && len(referenced) == 0 && len(e.Missing) == 0 {
+		if strings.TrimSpace(args) != "" {
+			e.Parts = append(e.Parts, ArgumentPart{Text: "\n\nARGUMENTS: " + args, Kind: PartAppended})
+		}
+		return e
+	}
+	if !usesAll {
+		for i := range e.Args {
+			if !referenced[i+1] {
+				e.Unreferenced = append(e.Unreferenced, i+1)
+			}
+		}
+	}
+	return e
+}
+
+// SplitArguments splits an argument string into words, honouring single
+// and double quotes
+func SplitArguments(s string) []string {
+	var words []string
+	var current strings.Builder
+	var quote rune
+	inWord := false
+
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, current.String())
+	}
+	return words
+}
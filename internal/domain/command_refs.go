@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+)
+
+// FileReference is an @path in a command body. Claude Code reads the file
+// into the prompt when the command runs.
+type FileReference struct {
+	Path string
+	// Line is the 1-based line of the reference in the command file
+	Line int
+	// Missing is set by the loader when the file does not exist
+	Missing bool
+}
+
+// Dynamic reports whether the path depends on the command's arguments,
+// e.g. "@src/$1.go", so it cannot be checked ahead of time
+func (r FileReference) Dynamic() bool {
+	return strings.Contains(r.Path, "$")
+}
+
+// ShellSnippet is a !`command` in a command body. Claude Code runs it
+// before the prompt is sent and inlines its output.
+type ShellSnippet struct {
+	Command string
+	Line    int
+}
+
+var (
+	fileReferencePattern = regexp.MustCompile("(?:^|[\\s(])@([^\\s`]+)")
+	shellSnippetPattern  = regexp.MustCompile("!`([^`\n]+)`")
+)
+
+// ExtractFileReferences finds the @path references in body. Only words
+// that look like paths count, so "@code-reviewer" mentions are skipped.
+// Lines are counted from the start of body.
+func ExtractFileReferences(body string) []FileReference {
+	var refs []FileReference
+	for i, line := range strings.Split(body, "\n") {
+		// Snippets are shell, not prompt text
+		line = shellSnippetPattern.ReplaceAllString(line, "")
+		for _, m := range fileReferencePattern.FindAllStringSubmatch(line, -1) {
+			path := strings.TrimRight(m[1], ".,;:!?)]}'\"")
+			if !strings.ContainsAny(path, "/.~$") {
+				continue
+			}
+			refs = append(refs, FileReference{Path: path, Line: i + 1})
+		}
+	}
+	return refs
+}
+
+// ExtractShellSnippets finds the !`command` snippets in body. Lines are
+// counted from the start of body.
+func ExtractShellSnippets(body string) []ShellSnippet {
+	var snippets []ShellSnippet
+	for i, line := range strings.Split(body, "\n") {
+		for _, m := range shellSnippetPattern.FindAllStringSubmatch(line, -1) {
+			snippets = append(snippets, ShellSnippet{Command: strings.TrimSpace(m[1]), Line: i + 1})
+		}
+	}
+	return snippets
+}
@@ -1,22 +1,63 @@
 package domain
 
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// MCPTransport is the transport Claude Code uses to talk to an MCP server
+type MCPTransport string
+
+const (
+	TransportStdio   MCPTransport = "stdio"
+	TransportSSE     MCPTransport = "sse"
+	TransportHTTP    MCPTransport = "http"
+	TransportUnknown MCPTransport = "unknown"
+)
+
+var mcpServerNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// ValidateMCPServerName checks a name given to a new or renamed server.
+// Server names are config keys, so unlike markdown capabilities they may
+// use capitals, underscores and dots.
+func ValidateMCPServerName(name string) error {
+	if !mcpServerNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid MCP server name %q: use letters, digits, '_', '.' and '-'", name)
+	}
+	return nil
+}
+
 type MCPServer struct {
 	Capability
-	Command string
-	Args    []string
-	Env     map[string]string
-	MCPType string
-	Url     string
+	FilePath      string
+	Command       string
+	Args          []string
+	Env           map[string]string
+	MCPType       string
+	Url           string
+	Headers       map[string]string
+	HeadersHelper string
+	Timeout       int
+	Extra         map[string]json.RawMessage
+
+	// Line is where the entry starts in FilePath, or 0 when unknown
+	Line int
 }
 
 type MCPServerParams struct {
-	Name    string
-	Scope   CapabilityScope
-	Command string
-	Args    []string
-	Env     map[string]string
-	MCPType string
-	Url     string
+	Name          string
+	Scope         CapabilityScope
+	FilePath      string
+	Command       string
+	Args          []string
+	Env           map[string]string
+	MCPType       string
+	Url           string
+	Headers       map[string]string
+	HeadersHelper string
+	Timeout       int
+	Extra         map[string]json.RawMessage
 }
 
 func NewMCPServer(params MCPServerParams) *MCPServer {
@@ -26,10 +67,94 @@ func NewMCPServer(params MCPServerParams) *MCPServer {
 			Type:  TypeMCP,
 			Scope: params.Scope,
 		},
-		Command: params.Command,
-		Args:    params.Args,
-		Env:     params.Env,
-		MCPType: params.MCPType,
-		Url:     params.Url,
+		FilePath:      params.FilePath,
+		Command:       params.Command,
+		Args:          params.Args,
+		Env:           params.Env,
+		MCPType:       params.MCPType,
+		Url:           params.Url,
+		Headers:       params.Headers,
+		HeadersHelper: params.HeadersHelper,
+		Timeout:       params.Timeout,
+		Extra:         params.Extra,
+	}
+}
+
+// Clone returns a copy of the server that can be modified without touching
+// the original's maps and slices
+func (s *MCPServer) Clone() *MCPServer {
+	clone := *s
+	clone.Args = append([]string(nil), s.Args...)
+	clone.Env = cloneStringMap(s.Env)
+	clone.Headers = cloneStringMap(s.Headers)
+	if s.Extra != nil {
+		clone.Extra = make(map[string]json.RawMessage, len(s.Extra))
+		for k, v := range s.Extra {
+			clone.Extra[k] = v
+		}
+	}
+	return &clone
+}
+
+// Transport returns the transport Claude Code will use for the server.
+// A missing type means stdio, matching how Claude Code reads the config.
+func (s *MCPServer) Transport() MCPTransport {
+	switch s.MCPType {
+	case "", string(TransportStdio):
+		return TransportStdio
+	case string(TransportSSE):
+		return TransportSSE
+	case string(TransportHTTP):
+		return TransportHTTP
+	default:
+		return TransportUnknown
+	}
+}
+
+// ConfigProblems lists the ways the declared type disagrees with the fields
+// that are actually present in the config
+func (s *MCPServer) ConfigProblems() []string {
+	var problems []string
+	remoteFields := s.Url != "" || len(s.Headers) > 0 || s.HeadersHelper != ""
+	stdioFields := s.Command != "" || len(s.Args) > 0 || len(s.Env) > 0
+
+	switch s.Transport() {
+	case TransportStdio:
+		if s.MCPType == "" && s.Url != "" && s.Command == "" {
+			problems = append(problems, "type is missing but url is set; add \"type\": \"http\" or \"sse\"")
+			break
+		}
+		if s.Command == "" {
+			problems = append(problems, "stdio server has no command")
+		}
+		if remoteFields {
+			problems = append(problems, "url/headers are ignored for stdio servers")
+		}
+	case TransportSSE, TransportHTTP:
+		if s.Url == "" {
+			problems = append(problems, fmt.Sprintf("%s server has no url", s.MCPType))
+		}
+		if stdioFields {
+			problems = append(problems, fmt.Sprintf("command/args/env are ignored for %s servers", s.MCPType))
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("unknown type %q (expected stdio, sse or http)", s.MCPType))
+	}
+
+	if s.Timeout < 0 {
+		problems = append(problems, "timeout must not be negative")
+	}
+
+	return problems
+}
+
+func cloneStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	clone := make(map[string]string, len(m))
+	for k, v := range m {
+		clone[k] = v
 	}
+	return clone
 }
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"os"
+	"regexp"
+)
+
+var variablePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// ExpandVariables resolves ${VAR} and ${VAR:-default} the way Claude Code
+// does when it starts a server. Unset variables without a default are left
+// in place and returned.
+func ExpandVariables(s string, lookup func(string) (string, bool)) (string, []string) {
+	var missing []string
+	expanded := variablePattern.ReplaceAllStringFunc(s, func(match string) string {
+		parts := variablePattern.FindStringSubmatch(match)
+		if value, ok := lookup(parts[1]); ok {
+			return value
+		}
+		if parts[2] != "" {
+			return parts[3]
+		}
+		missing = append(missing, parts[1])
+		return match
+	})
+	return expanded, missing
+}
+
+// Expanded returns a copy of the server with variables in command, args,
+// env, url and headers resolved from the process environment, plus the
+// variables that could not be resolved
+func (s *MCPServer) Expanded() (*MCPServer, []string) {
+	var missing []string
+	expand := func(v string) string {
+		expanded, unset := ExpandVariables(v, os.LookupEnv)
+		missing = append(missing, unset...)
+		return expanded
+	}
+
+	e := s.Clone()
+	e.Command = expand(e.Command)
+	e.Url = expand(e.Url)
+	for i, arg := range e.Args {
+		e.Args[i] = expand(arg)
+	}
+	for k, v := range e.Env {
+		e.Env[k] = expand(v)
+	}
+	for k, v := range e.Headers {
+		e.Headers[k] = expand(v)
+	}
+	return e, missing
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SecretReference records a hardcoded secret that was replaced by an
+// environment variable reference
+type SecretReference struct {
+	Variable string
+	Field    string
+	Value    string
+}
+
+// ExtractSecretsByDefault reports whether hardcoded secrets are replaced
+// when a server is copied into scope. Project servers live in .mcp.json,
+// which is usually committed.
+func ExtractSecretsByDefault(scope CapabilityScope) bool {
+	return scope == ScopeProject
+}
+
+// Export returns a shell line that sets the variable to the secret. The
+// value is single-quoted so the shell expands nothing in it.
+func (r SecretReference) Export() string {
+	return "export " + r.Variable + "='" + strings.ReplaceAll(r.Value, "'", `'\''`) + "'"
+}
+
+var (
+	secretNamePattern   = regexp.MustCompile(`(?i)(token|secret|password|passwd|api[-_]?key|access[-_]?key|private[-_]?key|credential|auth|^pat$|_pat$)`)
+	secretValuePattern  = regexp.MustCompile(`^(ghp_|gho_|ghu_|ghs_|github_pat_|glpat-|sk-|xox[abpr]-|AKIA|AIza|npm_|hf_)`)
+	envReferencePattern = regexp.MustCompile(`\$\{[A-Za-z_][A-Za-z0-9_]*(:-[^}]*)?\}`)
+	nonIdentifierChars  = regexp.MustCompile(`[^A-Za-z0-9]+`)
+)
+
+// LooksLikeSecret reports whether a value stored under name is probably a
+// credential. Values that already reference a variable never count.
+func LooksLikeSecret(name, value string) bool {
+	if value == "" || envReferencePattern.MatchString(value) {
+		return false
+	}
+	return secretNamePattern.MatchString(name) || secretValuePattern.MatchString(value)
+}
+
+// ReplaceSecrets swaps hardcoded secrets in env, headers and arguments for
+// ${VAR} references, which Claude Code expands when it starts the server.
+// It returns the variables that now have to be set.
+func (s *MCPServer) ReplaceSecrets() []SecretReference {
+	var refs []SecretReference
+	prefix := envVarName(s.Name)
+
+	for _, key := range sortedMapKeys(s.Env) {
+		if value := s.Env[key]; LooksLikeSecret(key, value) {
+			refs = append(refs, SecretReference{Variable: key, Field: "env." + key, Value: value})
+			s.Env[key] = "${" + key + "}"
+		}
+	}
+
+	for _, name := range sortedMapKeys(s.Headers) {
+		value := s.Headers[name]
+		if !LooksLikeSecret(name, value) {
+			continue
+		}
+		variable := prefix + "_" + envVarName(name)
+		scheme, token, hasScheme := strings.Cut(value, " ")
+		if strings.EqualFold(name, "Authorization") && hasScheme {
+			variable = prefix + "_TOKEN"
+			refs = append(refs, SecretReference{Variable: variable, Field: "headers." + name, Value: token})
+			s.Headers[name] = scheme + " ${" + variable + "}"
+			continue
+		}
+		refs = append(refs, SecretReference{Variable: variable, Field: "headers." + name, Value: value})
+		s.Headers[name] = "${" + variable + "}"
+	}
+
+	for i, arg := range s.Args {
+		flagName, value, isAssignment := strings.Cut(arg, "=")
+		switch {
+		case isAssignment && strings.HasPrefix(flagName, "-") && LooksLikeSecret(flagName, value):
+			variable := prefix + "_" + envVarName(flagName)
+			refs = append(refs, SecretReference{Variable: variable, Field: fmt.Sprintf("args[%d]", i), Value: value})
+			s.Args[i] = flagName + "=${" + variable + "}"
+		case i > 0 && strings.HasPrefix(s.Args[i-1], "-") && !strings.Contains(s.Args[i-1], "=") &&
+			!strings.HasPrefix(arg, "-") && LooksLikeSecret(s.Args[i-1], arg):
+			variable := prefix + "_" + envVarName(s.Args[i-1])
+			refs = append(refs, SecretReference{Variable: variable, Field: fmt.Sprintf("args[%d]", i), Value: arg})
+			s.Args[i] = "${" + variable + "}"
+		case secretValuePattern.MatchString(arg):
+			variable := fmt.Sprintf("%s_ARG%d", prefix, i)
+			refs = append(refs, SecretReference{Variable: variable, Field: fmt.Sprintf("args[%d]", i), Value: arg})
+			s.Args[i] = "${" + variable + "}"
+		}
+	}
+
+	return refs
+}
+
+// envVarName turns an arbitrary name into an upper-case identifier
+func envVarName(name string) string {
+	name = strings.Trim(nonIdentifierChars.ReplaceAllString(name, "_"), "_")
+	if name == "" {
+		return "MCP"
+	}
+	return strings.ToUpper(name)
+}
+
+func sortedMapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
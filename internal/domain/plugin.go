@@ -19,6 +19,11 @@ type Plugin struct {
 	Skills     []Skill      `json:"skills,omitempty"`
 	Agents     []Agent      `json:"agents,omitempty"`
 	Path       string       `json:"path,omitempty"`
+	// Key is the registry key, name@marketplace
+	Key string `json:"key,omitempty"`
+	// LatestVersion is the version the plugin's marketplace offers, or ""
+	// when unknown
+	LatestVersion string `json:"latest_version,omitempty"`
 }
 
 func (p *Plugin) CapabilityCount() int {
@@ -26,6 +31,43 @@ func (p *Plugin) CapabilityCount() int {
 		len(p.Skills) + len(p.Agents)
 }
 
+// MCPServerName is the name Claude Code gives a server the plugin ships,
+// so its tools are called mcp__plugin_<plugin>_<server>__<tool>
+func (p *Plugin) MCPServerName(server string) string {
+	return "plugin_" + p.Name + "_" + server
+}
+
+// LaunchableServer returns a copy of one of the plugin's servers under the
+// name Claude Code gives it, with ${CLAUDE_PLUGIN_ROOT} resolved to the
+// install directory
+func (p *Plugin) LaunchableServer(server MCPServer) *MCPServer {
+	lookup := func(name string) (string, bool) {
+		return p.Path, name == "CLAUDE_PLUGIN_ROOT"
+	}
+	expand := func(v string) string {
+		expanded, _ := ExpandVariables(v, lookup)
+		return expanded
+	}
+
+	s := server.Clone()
+	s.Name = p.MCPServerName(server.Name)
+	s.Command = expand(s.Command)
+	s.Url = expand(s.Url)
+	for i, arg := range s.Args {
+		s.Args[i] = expand(arg)
+	}
+	for k, v := range s.Env {
+		s.Env[k] = expand(v)
+	}
+	return s
+}
+
+// HasUpdate reports whether the marketplace offers a different version
+// from the installed one
+func (p *Plugin) HasUpdate() bool {
+	return p.LatestVersion != "" && p.LatestVersion != p.Version
+}
+
 func (p *Plugin) GetType() CapabilityType {
 	return TypePlugin
 }
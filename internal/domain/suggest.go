@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSuggestions caps the names offered for a misspelled one
+const maxSuggestions = 3
+
+// Suggest returns the candidates that s may be a misspelling of, closest
+// first: those within a few edits of s and those containing it, ignoring
+// case
+func Suggest(s string, candidates []string) []string {
+	type match struct {
+		name     string
+		distance int
+	}
+	lower := strings.ToLower(s)
+	limit := max(2, len(s)/3)
+
+	var matches []match
+	seen := map[string]bool{}
+	for _, candidate := range candidates {
+		if seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+		lowerCandidate := strings.ToLower(candidate)
+		d := editDistance(lower, lowerCandidate)
+		if d > limit && !strings.Contains(lowerCandidate, lower) {
+			continue
+		}
+		matches = append(matches, match{candidate, d})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+
+	var names []string
+	for _, m := range matches[:min(len(matches), maxSuggestions)] {
+		names = append(names, m.name)
+	}
+	return names
+}
@@ -0,0 +1,229 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// BuiltinTools are the tools Claude Code provides itself, as they are
+// written in allowed-tools and agent tools lists
+var BuiltinTools = []string{
+	"AskUserQuestion",
+	"Bash",
+	"BashOutput",
+	"Edit",
+	"ExitPlanMode",
+	"Glob",
+	"Grep",
+	"KillShell",
+	"ListMcpResourcesTool",
+	"MultiEdit",
+	"NotebookEdit",
+	"NotebookRead",
+	"Read",
+	"ReadMcpResourceTool",
+	"SlashCommand",
+	"Skill",
+	"Task",
+	"TodoWrite",
+	"WebFetch",
+	"WebSearch",
+	"Write",
+}
+
+// BuiltinSlashCommands are the commands built into Claude Code. Custom
+// commands with these names are shadowed.
+var BuiltinSlashCommands = []string{
+	"add-dir", "agents", "bashes", "bug", "clear", "compact", "config",
+	"context", "cost", "doctor", "exit", "export", "help", "hooks", "ide",
+	"init", "install-github-app", "login", "logout", "mcp", "memory",
+	"model", "output-style", "permissions", "plugin", "pr-comments",
+	"privacy-settings", "release-notes", "resume", "review", "rewind",
+	"sandbox", "security-review", "status", "statusline", "terminal-setup",
+	"todos", "upgrade", "usage", "vim",
+}
+
+// ModelAliases are the model names Claude Code accepts besides full model
+// IDs. "inherit" is only meaningful for agents.
+var ModelAliases = []string{"sonnet", "opus", "haiku", "opusplan", "inherit"}
+
+// ToolRule is one entry of an allowed-tools or tools list, such as
+// "Bash(git diff:*)" or "mcp__github__create_issue"
+type ToolRule struct {
+	Tool    string
+	Pattern string
+}
+
+var toolRulePattern = regexp.MustCompile(`^([A-Za-z0-9_*-]+)(?:\((.*)\))?$`)
+
+// ParseToolRule splits an entry into its tool name and optional pattern.
+// It returns false when the entry is not well-formed.
+func ParseToolRule(s string) (ToolRule, bool) {
+	m := toolRulePattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return ToolRule{}, false
+	}
+	return ToolRule{Tool: m[1], Pattern: m[2]}, true
+}
+
+// SplitToolList splits a comma-separated tools value. Commas inside
+// parentheses belong to the pattern.
+func SplitToolList(s string) []string {
+	var entries []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				entries = appendTrimmed(entries, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return appendTrimmed(entries, s[start:])
+}
+
+func appendTrimmed(entries []string, s string) []string {
+	if s = strings.TrimSpace(s); s != "" {
+		entries = append(entries, s)
+	}
+	return entries
+}
+
+// IsBuiltinTool reports whether name is one of BuiltinTools
+func IsBuiltinTool(name string) bool {
+	for _, tool := range BuiltinTools {
+		if tool == name {
+			return true
+		}
+	}
+	return false
+}
+
+// MCPToolServer returns the server of an mcp__server__tool name, or of
+// mcp__server which grants every tool of that server
+func MCPToolServer(name string) (server string, ok bool) {
+	rest, ok := strings.CutPrefix(name, "mcp__")
+	if !ok || rest == "" {
+		return "", false
+	}
+	server, _, _ = strings.Cut(rest, "__")
+	return server, server != ""
+}
+
+var (
+	ErrUnknownTool      = errors.New("unknown tool")
+	ErrUnknownMCPServer = errors.New("unknown MCP server")
+)
+
+// CheckTool resolves a tool name against the built-in tools and the
+// mcp__<server>__<tool> names of servers. A nil servers skips the server
+// check. Plugin servers (mcp__plugin_...) are not checked.
+func CheckTool(name string, servers []string) error {
+	if IsBuiltinTool(name) {
+		return nil
+	}
+	if server, ok := MCPToolServer(name); ok {
+		if servers == nil || strings.HasPrefix(server, "plugin_") || slices.Contains(servers, server) {
+			return nil
+		}
+		if suggestion := closest(server, servers); suggestion != "" {
+			return fmt.Errorf("%w %q in %s; did you mean %q?", ErrUnknownMCPServer, server, name, suggestion)
+		}
+		return fmt.Errorf("%w %q in %s", ErrUnknownMCPServer, server, name)
+	}
+	if suggestion := closest(name, BuiltinTools); suggestion != "" {
+		return fmt.Errorf("%w %q; did you mean %q?", ErrUnknownTool, name, suggestion)
+	}
+	return fmt.Errorf("%w %q", ErrUnknownTool, name)
+}
+
+// closest returns the candidate within two edits of s, ignoring case
+func closest(s string, candidates []string) string {
+	best, bestDistance := "", 3
+	for _, candidate := range candidates {
+		if d := editDistance(strings.ToLower(s), strings.ToLower(candidate)); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// AllowsBash reports whether an allowed-tools list permits running
+// command. Compound commands need every part to be permitted.
+func AllowsBash(allowedTools []string, command string) bool {
+	for _, part := range splitShellCommand(command) {
+		if !allowsBashPart(allowedTools, part) {
+			return false
+		}
+	}
+	return true
+}
+
+func allowsBashPart(allowedTools []string, command string) bool {
+	for _, entry := range allowedTools {
+		rule, ok := ParseToolRule(entry)
+		if !ok || rule.Tool != "Bash" {
+			continue
+		}
+		pattern := strings.TrimSpace(rule.Pattern)
+		switch {
+		case pattern == "" || pattern == "*":
+			return true
+		case strings.HasSuffix(pattern, ":*"):
+			// Bash(git diff:*) matches "git diff" and anything after it
+			prefix := strings.TrimSuffix(pattern, ":*")
+			if command == prefix || strings.HasPrefix(command, prefix+" ") {
+				return true
+			}
+		case strings.HasSuffix(pattern, "*"):
+			if strings.HasPrefix(command, strings.TrimSuffix(pattern, "*")) {
+				return true
+			}
+		case command == pattern:
+			return true
+		}
+	}
+	return false
+}
+
+var shellOperatorPattern = regexp.MustCompile(`&&|\|\||[;|]`)
+
+// splitShellCommand splits a command on &&, ||, ; and pipes
+func splitShellCommand(command string) []string {
+	var parts []string
+	for _, part := range shellOperatorPattern.Split(command, -1) {
+		parts = appendTrimmed(parts, part)
+	}
+	return parts
+}
@@ -0,0 +1,115 @@
+// Package editor opens capability files in the user's editor
+package editor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"claudectl/internal/loaders"
+	"claudectl/internal/viewmodels"
+)
+
+// Editors that accept +LINE before the file
+var plusLineEditors = map[string]bool{
+	"vi": true, "vim": true, "nvim": true, "gvim": true, "mvim": true,
+	"nano": true, "pico": true, "emacs": true, "emacsclient": true,
+	"micro": true, "kak": true, "joe": true, "ne": true, "mg": true,
+}
+
+// GUI editors that return immediately unless told to wait, with their
+// wait flag and whether they take --goto FILE:LINE or FILE:LINE
+var guiEditors = map[string]struct {
+	wait string
+	goTo bool
+}{
+	"code":          {"--wait", true},
+	"code-insiders": {"--wait", true},
+	"codium":        {"--wait", true},
+	"cursor":        {"--wait", true},
+	"windsurf":      {"--wait", true},
+	"subl":          {"--wait", false},
+	"zed":           {"--wait", false},
+}
+
+// Command builds the command that opens path in $VISUAL or $EDITOR,
+// positioned at line when it is greater than zero
+func Command(path string, line int) (*exec.Cmd, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	words := strings.Fields(editor)
+	if _, err := exec.LookPath(words[0]); err != nil {
+		return nil, fmt.Errorf("editor %q not found; set $VISUAL or $EDITOR", words[0])
+	}
+
+	args := append(words[1:], fileArgs(words, path, line)...)
+	cmd := exec.Command(words[0], args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd, nil
+}
+
+func fileArgs(words []string, path string, line int) []string {
+	name := strings.TrimSuffix(filepath.Base(words[0]), ".exe")
+	var args []string
+
+	if gui, ok := guiEditors[name]; ok {
+		if !containsAny(words[1:], gui.wait, "-w") {
+			args = append(args, gui.wait)
+		}
+		if line <= 0 {
+			return append(args, path)
+		}
+		if gui.goTo {
+			args = append(args, "--goto")
+		}
+		return append(args, path+":"+strconv.Itoa(line))
+	}
+
+	switch {
+	case line <= 0:
+		return []string{path}
+	case plusLineEditors[name]:
+		return []string{"+" + strconv.Itoa(line), path}
+	case name == "hx" || name == "helix":
+		return []string{path + ":" + strconv.Itoa(line)}
+	default:
+		return []string{path}
+	}
+}
+
+// Location returns the file to edit for a capability and the line to
+// open it at. Plugins open their manifest when they have one.
+func Location(vm viewmodels.CapabilityViewModel) (string, int) {
+	switch v := vm.(type) {
+	case *viewmodels.MCPServerViewModel:
+		return v.GetFilePath(), v.Server().Line
+	case *viewmodels.PluginViewModel:
+		manifest := loaders.PluginManifestPath(v.GetFilePath())
+		if _, err := os.Stat(manifest); err == nil {
+			return manifest, 0
+		}
+	}
+	return vm.GetFilePath(), 0
+}
+
+func containsAny(words []string, candidates ...string) bool {
+	for _, word := range words {
+		for _, candidate := range candidates {
+			if word == candidate {
+				return true
+			}
+		}
+	}
+	return false
+}
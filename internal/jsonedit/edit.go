@@ -0,0 +1,343 @@
+// Package jsonedit makes targeted edits to JSON documents without
+// re-serializing them, so key order, formatting and unknown keys are kept
+// exactly as they were.
+package jsonedit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotFound is returned when a path does not exist in the document
+var ErrNotFound = errors.New("path not found")
+
+// Find returns the raw value stored at path
+func Find(data []byte, path ...string) (json.RawMessage, error) {
+	root, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+	n, err := lookup(root, path)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(data[n.start:n.end]), nil
+}
+
+// Offset returns the byte offset where the member at path starts: the key
+// for object members, or the value itself for the document root
+func Offset(data []byte, path ...string) (int, error) {
+	root, err := parse(data)
+	if err != nil {
+		return 0, err
+	}
+	if len(path) == 0 {
+		return root.start, nil
+	}
+	parent, err := lookup(root, path[:len(path)-1])
+	if err != nil {
+		return 0, err
+	}
+	idx := objectMember(parent, path[len(path)-1])
+	if idx < 0 {
+		return 0, fmt.Errorf("%w: %s", ErrNotFound, strings.Join(path, "."))
+	}
+	return parent.members[idx].keyStart, nil
+}
+
+// Keys returns the keys of the object at path in document order
+func Keys(data []byte, path ...string) ([]string, error) {
+	root, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+	n, err := lookup(root, path)
+	if err != nil {
+		return nil, err
+	}
+	if n.kind != '{' {
+		return nil, fmt.Errorf("%s is not an object", strings.Join(path, "."))
+	}
+	keys := make([]string, 0, len(n.members))
+	for _, m := range n.members {
+		keys = append(keys, m.key)
+	}
+	return keys, nil
+}
+
+// Set stores value at path. An existing value is replaced in place; a new
+// key is appended to its parent object, creating missing parent objects.
+func Set(data []byte, value any, path ...string) ([]byte, error) {
+	if len(path) == 0 {
+		return nil, errors.New("empty path")
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		data = []byte("{}\n")
+	}
+
+	root, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+	style := detectStyle(data)
+
+	// Walk down to the deepest existing object on the path
+	n := root
+	depth := 0
+	for ; depth < len(path); depth++ {
+		if n.kind != '{' {
+			return nil, fmt.Errorf("%s is not an object", strings.Join(path[:depth], "."))
+		}
+		idx := n.member(path[depth])
+		if idx < 0 {
+			break
+		}
+		if depth == len(path)-1 {
+			m := n.members[idx]
+			encoded, err := style.encode(value, lineIndent(data, m.keyStart))
+			if err != nil {
+				return nil, err
+			}
+			return splice(data, m.value.start, m.value.end, encoded), nil
+		}
+		n = n.members[idx].value
+	}
+	if n.kind != '{' {
+		return nil, fmt.Errorf("%s is not an object", strings.Join(path[:depth], "."))
+	}
+
+	// Wrap the value in the objects that are missing
+	var nested any = value
+	for i := len(path) - 1; i > depth; i-- {
+		nested = orderedObject{key: path[i], value: nested}
+	}
+	return insertMember(data, n, path[depth], nested, style)
+}
+
+// Delete removes the member at path together with its separator
+func Delete(data []byte, path ...string) ([]byte, error) {
+	if len(path) == 0 {
+		return nil, errors.New("empty path")
+	}
+	root, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+	parent, err := lookup(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	idx := objectMember(parent, path[len(path)-1])
+	if idx < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, strings.Join(path, "."))
+	}
+
+	members := parent.members
+	switch {
+	case len(members) == 1:
+		return splice(data, parent.start+1, parent.end-1, nil), nil
+	case idx > 0:
+		return splice(data, members[idx-1].value.end, members[idx].value.end, nil), nil
+	default:
+		return splice(data, members[0].keyStart, members[1].keyStart, nil), nil
+	}
+}
+
+// RenameKey changes the key of the member at path, keeping its position
+func RenameKey(data []byte, newKey string, path ...string) ([]byte, error) {
+	if len(path) == 0 {
+		return nil, errors.New("empty path")
+	}
+	root, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+	parent, err := lookup(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	idx := objectMember(parent, path[len(path)-1])
+	if idx < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, strings.Join(path, "."))
+	}
+	if parent.member(newKey) >= 0 {
+		return nil, fmt.Errorf("key %q already exists", newKey)
+	}
+
+	encoded, err := marshal(newKey, "", "")
+	if err != nil {
+		return nil, err
+	}
+	m := parent.members[idx]
+	return splice(data, m.keyStart, m.keyEnd, encoded), nil
+}
+
+// Line returns the 1-based line number of a byte offset
+func Line(data []byte, offset int) int {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+func lookup(root *node, path []string) (*node, error) {
+	n := root
+	for i, key := range path {
+		idx := objectMember(n, key)
+		if idx < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, strings.Join(path[:i+1], "."))
+		}
+		n = n.members[idx].value
+	}
+	return n, nil
+}
+
+func objectMember(n *node, key string) int {
+	if n.kind != '{' {
+		return -1
+	}
+	return n.member(key)
+}
+
+func insertMember(data []byte, obj *node, key string, value any, style style) ([]byte, error) {
+	encodedKey, err := marshal(key, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	if !style.multiline {
+		encoded, err := style.encode(value, "")
+		if err != nil {
+			return nil, err
+		}
+		entry := append(append(encodedKey, ':'), encoded...)
+		if len(obj.members) == 0 {
+			return splice(data, obj.start+1, obj.end-1, entry), nil
+		}
+		last := obj.members[len(obj.members)-1]
+		return splice(data, last.value.end, last.value.end, append([]byte{','}, entry...)), nil
+	}
+
+	var memberIndent string
+	if len(obj.members) > 0 {
+		memberIndent = lineIndent(data, obj.members[len(obj.members)-1].keyStart)
+	} else {
+		memberIndent = lineIndent(data, obj.start) + style.indent
+	}
+
+	encoded, err := style.encode(value, memberIndent)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry bytes.Buffer
+	if len(obj.members) > 0 {
+		entry.WriteString(",")
+	}
+	entry.WriteString(style.newline + memberIndent)
+	entry.Write(encodedKey)
+	entry.WriteString(": ")
+	entry.Write(encoded)
+
+	if len(obj.members) == 0 {
+		entry.WriteString(style.newline + lineIndent(data, obj.start))
+		return splice(data, obj.start+1, obj.end-1, entry.Bytes()), nil
+	}
+	last := obj.members[len(obj.members)-1]
+	return splice(data, last.value.end, last.value.end, entry.Bytes()), nil
+}
+
+func splice(data []byte, start, end int, replacement []byte) []byte {
+	out := make([]byte, 0, len(data)-(end-start)+len(replacement))
+	out = append(out, data[:start]...)
+	out = append(out, replacement...)
+	out = append(out, data[end:]...)
+	return out
+}
+
+// lineIndent returns the leading whitespace of the line containing offset
+func lineIndent(data []byte, offset int) string {
+	lineStart := bytes.LastIndexByte(data[:offset], '\n') + 1
+	end := lineStart
+	for end < len(data) && (data[end] == ' ' || data[end] == '\t') {
+		end++
+	}
+	return string(data[lineStart:end])
+}
+
+// style captures how an existing document is formatted so inserted values
+// look like they were written by the same tool
+type style struct {
+	multiline bool
+	indent    string
+	newline   string
+}
+
+func detectStyle(data []byte) style {
+	s := style{indent: "  ", newline: "\n"}
+	trimmed := bytes.TrimSpace(data)
+	if !bytes.Contains(trimmed, []byte("\n")) && len(trimmed) > 2 {
+		return s
+	}
+	s.multiline = true
+	if bytes.Contains(data, []byte("\r\n")) {
+		s.newline = "\r\n"
+	}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		indent := lineIndent(line, 0)
+		if indent != "" {
+			s.indent = indent
+			break
+		}
+	}
+	return s
+}
+
+func (s style) encode(value any, prefix string) ([]byte, error) {
+	if !s.multiline {
+		return marshal(value, "", "")
+	}
+	encoded, err := marshal(value, prefix, s.indent)
+	if err != nil {
+		return nil, err
+	}
+	if s.newline != "\n" {
+		encoded = bytes.ReplaceAll(encoded, []byte("\n"), []byte(s.newline))
+	}
+	return encoded, nil
+}
+
+func marshal(value any, prefix, indent string) ([]byte, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if indent != "" {
+		enc.SetIndent(prefix, indent)
+	}
+	if err := enc.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(b.Bytes(), "\n"), nil
+}
+
+// orderedObject is a single-key object used when Set creates missing parents
+type orderedObject struct {
+	key   string
+	value any
+}
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	key, err := marshal(o.key, "", "")
+	if err != nil {
+		return nil, err
+	}
+	value, err := marshal(o.value, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return append(append(append([]byte{'{'}, key...), ':'), append(value, '}')...), nil
+}
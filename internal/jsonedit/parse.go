@@ -0,0 +1,213 @@
+package jsonedit
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// node is a parsed JSON value together with the byte range it occupies in
+// the source document. Objects keep their members in source order.
+type node struct {
+	kind    byte // '{', '[', '"' or 'v' for any other literal
+	start   int
+	end     int
+	members []member
+	elems   []*node
+}
+
+type member struct {
+	key      string
+	keyStart int
+	keyEnd   int
+	value    *node
+}
+
+type parser struct {
+	data []byte
+	pos  int
+}
+
+func parse(data []byte) (*node, error) {
+	p := &parser{data: data}
+	p.skipSpace()
+	root, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos != len(p.data) {
+		return nil, p.errorf("unexpected trailing data")
+	}
+	return root, nil
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid JSON at line %d: %s", Line(p.data, p.pos), fmt.Sprintf(format, args...))
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) value() (*node, error) {
+	if p.pos >= len(p.data) {
+		return nil, p.errorf("unexpected end of input")
+	}
+
+	switch c := p.data[p.pos]; {
+	case c == '{':
+		return p.object()
+	case c == '[':
+		return p.array()
+	case c == '"':
+		start := p.pos
+		if _, err := p.str(); err != nil {
+			return nil, err
+		}
+		return &node{kind: '"', start: start, end: p.pos}, nil
+	default:
+		return p.literal()
+	}
+}
+
+func (p *parser) object() (*node, error) {
+	n := &node{kind: '{', start: p.pos}
+	p.pos++
+	p.skipSpace()
+
+	if p.pos < len(p.data) && p.data[p.pos] == '}' {
+		p.pos++
+		n.end = p.pos
+		return n, nil
+	}
+
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) || p.data[p.pos] != '"' {
+			return nil, p.errorf("expected object key")
+		}
+		keyStart := p.pos
+		key, err := p.str()
+		if err != nil {
+			return nil, err
+		}
+		keyEnd := p.pos
+
+		p.skipSpace()
+		if p.pos >= len(p.data) || p.data[p.pos] != ':' {
+			return nil, p.errorf("expected ':' after object key")
+		}
+		p.pos++
+		p.skipSpace()
+
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		n.members = append(n.members, member{key: key, keyStart: keyStart, keyEnd: keyEnd, value: value})
+
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return nil, p.errorf("unterminated object")
+		}
+		switch p.data[p.pos] {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			n.end = p.pos
+			return n, nil
+		default:
+			return nil, p.errorf("expected ',' or '}'")
+		}
+	}
+}
+
+func (p *parser) array() (*node, error) {
+	n := &node{kind: '[', start: p.pos}
+	p.pos++
+	p.skipSpace()
+
+	if p.pos < len(p.data) && p.data[p.pos] == ']' {
+		p.pos++
+		n.end = p.pos
+		return n, nil
+	}
+
+	for {
+		p.skipSpace()
+		elem, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		n.elems = append(n.elems, elem)
+
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return nil, p.errorf("unterminated array")
+		}
+		switch p.data[p.pos] {
+		case ',':
+			p.pos++
+		case ']':
+			p.pos++
+			n.end = p.pos
+			return n, nil
+		default:
+			return nil, p.errorf("expected ',' or ']'")
+		}
+	}
+}
+
+func (p *parser) str() (string, error) {
+	start := p.pos
+	p.pos++
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case '\\':
+			p.pos += 2
+		case '"':
+			p.pos++
+			var s string
+			if err := json.Unmarshal(p.data[start:p.pos], &s); err != nil {
+				return "", p.errorf("invalid string: %v", err)
+			}
+			return s, nil
+		default:
+			p.pos++
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *parser) literal() (*node, error) {
+	start := p.pos
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		if c == ',' || c == '}' || c == ']' || c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+			break
+		}
+		p.pos++
+	}
+	if start == p.pos || !json.Valid(p.data[start:p.pos]) {
+		return nil, p.errorf("invalid value %q", p.data[start:p.pos])
+	}
+	return &node{kind: 'v', start: start, end: p.pos}, nil
+}
+
+// member returns the index of key in an object node, or -1
+func (n *node) member(key string) int {
+	for i, m := range n.members {
+		if m.key == key {
+			return i
+		}
+	}
+	return -1
+}
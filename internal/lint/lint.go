@@ -0,0 +1,273 @@
+// Package lint checks command, skill and agent files for mistakes that
+// Claude Code would silently ignore or reject
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"claudectl/internal/domain"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	// SeverityNote marks things worth knowing that are not mistakes
+	SeverityNote Severity = "note"
+)
+
+// Diagnostic is one problem found in a file. Line and Column are 1-based;
+// a zero Line refers to the file or directory as a whole.
+type Diagnostic struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Column   int      `json:"column"`
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"`
+	Message  string   `json:"message"`
+}
+
+// String formats d like a compiler message: file:line:col: severity: message
+func (d Diagnostic) String() string {
+	line, col := d.Line, d.Column
+	if line == 0 {
+		line, col = 1, 1
+	}
+	return fmt.Sprintf("%s:%d:%d: %s: %s (%s)", d.File, line, col, d.Severity, d.Message, d.Rule)
+}
+
+type Report struct {
+	Files       int          `json:"files"`
+	Errors      int          `json:"errors"`
+	Warnings    int          `json:"warnings"`
+	Notes       int          `json:"notes"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// target is a file to lint and what kind of capability it holds
+type target struct {
+	path string
+	kind domain.CapabilityType
+}
+
+// Options configures a lint run
+type Options struct {
+	// MCPServers are the configured server names that mcp__<server>__<tool>
+	// entries may refer to. Nil skips the check.
+	MCPServers []string
+}
+
+// Run lints the given files and directories. A directory can be a .claude
+// directory, a plugin or project root, or a commands, agents or skills
+// directory.
+func Run(paths []string, opts Options) (*Report, error) {
+	report := &Report{Diagnostics: []Diagnostic{}}
+	var targets []target
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			kind, err := classifyFile(path)
+			if err != nil {
+				return nil, err
+			}
+			targets = append(targets, target{path, kind})
+			continue
+		}
+
+		found, diagnostics, err := collectDir(path)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, found...)
+		report.Diagnostics = append(report.Diagnostics, diagnostics...)
+	}
+
+	for _, t := range targets {
+		data, err := os.ReadFile(t.path)
+		if err != nil {
+			return nil, err
+		}
+		report.Files++
+		report.Diagnostics = append(report.Diagnostics, lintFile(t, data, opts)...)
+	}
+
+	for i := range report.Diagnostics {
+		d := &report.Diagnostics[i]
+		d.File = displayPath(d.File)
+		switch d.Severity {
+		case SeverityError:
+			report.Errors++
+		case SeverityWarning:
+			report.Warnings++
+		default:
+			report.Notes++
+		}
+	}
+	sort.SliceStable(report.Diagnostics, func(i, j int) bool {
+		a, b := report.Diagnostics[i], report.Diagnostics[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return report, nil
+}
+
+var kindDirs = map[string]domain.CapabilityType{
+	"commands": domain.TypeCommand,
+	"agents":   domain.TypeAgent,
+	"skills":   domain.TypeSkill,
+}
+
+// collectDir finds the capability files under dir
+func collectDir(dir string) ([]target, []Diagnostic, error) {
+	if kind, ok := kindDirs[filepath.Base(dir)]; ok {
+		return collectKind(dir, kind)
+	}
+	if filepath.Base(filepath.Dir(dir)) == "skills" {
+		skillFile := filepath.Join(dir, "SKILL.md")
+		if _, err := os.Stat(skillFile); err != nil {
+			return nil, []Diagnostic{missingSkillFile(dir)}, nil
+		}
+		return []target{{skillFile, domain.TypeSkill}}, nil, nil
+	}
+
+	var targets []target
+	var diagnostics []Diagnostic
+	found := false
+	for _, name := range []string{"commands", "agents", "skills"} {
+		sub := filepath.Join(dir, name)
+		if info, err := os.Stat(sub); err != nil || !info.IsDir() {
+			continue
+		}
+		found = true
+		t, d, err := collectKind(sub, kindDirs[name])
+		if err != nil {
+			return nil, nil, err
+		}
+		targets = append(targets, t...)
+		diagnostics = append(diagnostics, d...)
+	}
+	if found {
+		return targets, diagnostics, nil
+	}
+
+	claudeDir := filepath.Join(dir, ".claude")
+	if info, err := os.Stat(claudeDir); err == nil && info.IsDir() {
+		return collectDir(claudeDir)
+	}
+	return nil, nil, fmt.Errorf("%s has no commands, agents or skills directory", dir)
+}
+
+func collectKind(dir string, kind domain.CapabilityType) ([]target, []Diagnostic, error) {
+	var targets []target
+	var diagnostics []Diagnostic
+
+	switch kind {
+	case domain.TypeCommand:
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && strings.HasSuffix(d.Name(), ".md") {
+				targets = append(targets, target{path, kind})
+			}
+			return nil
+		})
+		return targets, nil, err
+
+	case domain.TypeAgent:
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
+				targets = append(targets, target{filepath.Join(dir, entry.Name()), kind})
+			}
+		}
+		return targets, nil, nil
+
+	default:
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			skillDir := filepath.Join(dir, entry.Name())
+			skillFile := filepath.Join(skillDir, "SKILL.md")
+			if _, err := os.Stat(skillFile); errors.Is(err, fs.ErrNotExist) {
+				diagnostics = append(diagnostics, missingSkillFile(skillDir))
+				continue
+			}
+			targets = append(targets, target{skillFile, kind})
+		}
+		return targets, diagnostics, nil
+	}
+}
+
+func missingSkillFile(dir string) Diagnostic {
+	message := "skill directory has no SKILL.md, so Claude Code ignores it"
+	if entries, err := os.ReadDir(dir); err == nil {
+		for _, entry := range entries {
+			if strings.EqualFold(entry.Name(), "SKILL.md") {
+				message = fmt.Sprintf("skill file must be named SKILL.md, not %s", entry.Name())
+			}
+		}
+	}
+	return Diagnostic{File: dir, Severity: SeverityError, Rule: "skill-missing-file", Message: message}
+}
+
+// classifyFile works out what a single file is from where it lives
+func classifyFile(path string) (domain.CapabilityType, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	parent := filepath.Base(filepath.Dir(abs))
+	if filepath.Base(abs) == "SKILL.md" && filepath.Base(filepath.Dir(filepath.Dir(abs))) == "skills" {
+		return domain.TypeSkill, nil
+	}
+	if parent == "agents" {
+		return domain.TypeAgent, nil
+	}
+	for dir := filepath.Dir(abs); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if filepath.Base(dir) == "commands" {
+			return domain.TypeCommand, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not inside a commands, agents or skills directory", path)
+}
+
+// displayPath shortens paths below the working directory
+func displayPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(wd, abs); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
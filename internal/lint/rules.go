@@ -0,0 +1,356 @@
+package lint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	"claudectl/internal/domain"
+)
+
+const (
+	maxNameLength        = 64
+	maxDescriptionLength = 1024
+)
+
+var (
+	namePattern      = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	yamlErrorPattern = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+	// A key whose plain value has a second ": " in it
+	unquotedColonPattern = regexp.MustCompile(`^\s*[^\s:#][^:]*:\s+[^\s"'\[{|>&*!][^#]*?(:)\s`)
+)
+
+// document is a markdown file split into frontmatter fields and body
+type document struct {
+	hasFrontmatter bool
+	keys           map[string]*yaml.Node
+	values         map[string]*yaml.Node
+	body           string
+	bodyLine       int
+	lastLine       int
+}
+
+// field returns the string value of a scalar field
+func (d *document) field(key string) (string, bool) {
+	node, ok := d.values[key]
+	if !ok || node.Kind != yaml.ScalarNode {
+		return "", ok
+	}
+	return node.Value, true
+}
+
+// fileLine converts a line inside the frontmatter to a line in the file,
+// which starts after the opening ---
+func fileLine(line int) int {
+	return line + 1
+}
+
+// checker collects diagnostics for one file
+type checker struct {
+	path        string
+	servers     []string
+	diagnostics []Diagnostic
+}
+
+func (c *checker) report(severity Severity, line, col int, rule, format string, args ...any) {
+	c.diagnostics = append(c.diagnostics, Diagnostic{
+		File:     c.path,
+		Line:     line,
+		Column:   col,
+		Severity: severity,
+		Rule:     rule,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *checker) atKey(doc *document, key string, severity Severity, rule, format string, args ...any) {
+	line, col := 1, 1
+	if node, ok := doc.keys[key]; ok {
+		line, col = fileLine(node.Line), node.Column
+	}
+	c.report(severity, line, col, rule, format, args...)
+}
+
+func (c *checker) atValue(doc *document, key string, severity Severity, rule, format string, args ...any) {
+	line, col := 1, 1
+	if node, ok := doc.values[key]; ok {
+		line, col = fileLine(node.Line), node.Column
+	}
+	c.report(severity, line, col, rule, format, args...)
+}
+
+func lintFile(t target, data []byte, opts Options) []Diagnostic {
+	c := &checker{path: t.path, servers: opts.MCPServers}
+	doc, ok := c.parse(data)
+	if !ok {
+		return c.diagnostics
+	}
+
+	switch t.kind {
+	case domain.TypeCommand:
+		c.checkCommand(t, doc)
+	case domain.TypeSkill:
+		c.checkSkill(t, doc)
+	case domain.TypeAgent:
+		c.checkAgent(t, doc)
+	}
+
+	if strings.TrimSpace(doc.body) == "" {
+		c.report(SeverityWarning, doc.lastLine, 1, "empty-body", "%s has no instructions after the frontmatter", t.kind)
+	}
+	return c.diagnostics
+}
+
+// parse splits data into frontmatter and body. It returns false when the
+// frontmatter is too broken to check anything else.
+func (c *checker) parse(data []byte) (*document, bool) {
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	lines := strings.Split(string(data), "\n")
+	doc := &document{
+		keys:     map[string]*yaml.Node{},
+		values:   map[string]*yaml.Node{},
+		body:     string(data),
+		bodyLine: 1,
+		lastLine: len(lines),
+	}
+	if doc.lastLine > 1 && lines[doc.lastLine-1] == "" {
+		doc.lastLine--
+	}
+
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return doc, true
+	}
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		c.report(SeverityError, 1, 1, "frontmatter-invalid", "frontmatter is not closed with ---")
+		return nil, false
+	}
+
+	doc.hasFrontmatter = true
+	doc.body = strings.Join(lines[end+1:], "\n")
+	doc.bodyLine = end + 2
+
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[1:end], "\n")), &root); err != nil {
+		c.reportYAMLError(err, lines[1:end])
+		return nil, false
+	}
+	if len(root.Content) == 0 {
+		return doc, true
+	}
+
+	mapping := root.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		c.report(SeverityError, fileLine(mapping.Line), mapping.Column, "frontmatter-invalid", "frontmatter must be a set of key: value pairs")
+		return nil, false
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		if _, dup := doc.keys[key.Value]; dup {
+			c.report(SeverityError, fileLine(key.Line), key.Column, "frontmatter-invalid", "duplicate key %q", key.Value)
+		}
+		doc.keys[key.Value] = key
+		doc.values[key.Value] = value
+	}
+	return doc, true
+}
+
+// reportYAMLError points at the line a YAML error is on. The most common
+// mistake, an unquoted value containing ": ", is pinpointed exactly.
+func (c *checker) reportYAMLError(err error, frontmatter []string) {
+	message := strings.TrimPrefix(err.Error(), "yaml: ")
+	if strings.Contains(message, "mapping values are not allowed") {
+		for i, line := range frontmatter {
+			if m := unquotedColonPattern.FindStringSubmatchIndex(line); m != nil {
+				c.report(SeverityError, fileLine(i+1), m[2]+1, "frontmatter-invalid",
+					"value contains \": \"; wrap it in quotes")
+				return
+			}
+		}
+	}
+
+	line := 1
+	if m := yamlErrorPattern.FindStringSubmatch(err.Error()); m != nil {
+		line, _ = strconv.Atoi(m[1])
+		message = m[2]
+	}
+	c.report(SeverityError, fileLine(line), 1, "frontmatter-invalid", "invalid YAML frontmatter: %s", message)
+}
+
+func (c *checker) checkCommand(t target, doc *document) {
+	name := strings.TrimSuffix(filepath.Base(t.path), ".md")
+	if !namePattern.MatchString(name) {
+		c.report(SeverityWarning, 0, 0, "name-format", "command name %q should use lowercase letters, digits and hyphens", name)
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		c.report(SeverityWarning, 0, 0, "name-length", "command name is longer than %d characters", maxNameLength)
+	}
+	c.checkBuiltinCollision(doc, "", name)
+	c.checkDescription(doc, false, SeverityWarning)
+	c.checkTools(doc, "allowed-tools")
+	c.checkModel(doc, false)
+}
+
+func (c *checker) checkSkill(t target, doc *document) {
+	if !c.requireFrontmatter(t, doc) {
+		return
+	}
+	name, ok := c.checkName(doc, domain.TypeSkill)
+	if ok {
+		if dir := filepath.Base(filepath.Dir(t.path)); name != dir {
+			c.atValue(doc, "name", SeverityWarning, "name-mismatch", "skill name %q does not match its directory %q", name, dir)
+		}
+		c.checkBuiltinCollision(doc, "name", name)
+	}
+	c.checkDescription(doc, true, SeverityError)
+	c.checkTools(doc, "allowed-tools")
+	c.checkModel(doc, false)
+}
+
+func (c *checker) checkAgent(t target, doc *document) {
+	if !c.requireFrontmatter(t, doc) {
+		return
+	}
+	c.checkName(doc, domain.TypeAgent)
+	c.checkDescription(doc, true, SeverityWarning)
+	if c.checkTools(doc, "tools") == 0 {
+		c.report(SeverityNote, 1, 1, "tools-inherited", "agent has no tools list and can use every tool, including all MCP tools")
+	}
+	c.checkModel(doc, true)
+}
+
+func (c *checker) requireFrontmatter(t target, doc *document) bool {
+	if !doc.hasFrontmatter {
+		c.report(SeverityError, 1, 1, "frontmatter-missing", "%s files need frontmatter with name and description", t.kind)
+	}
+	return doc.hasFrontmatter
+}
+
+func (c *checker) checkName(doc *document, kind domain.CapabilityType) (string, bool) {
+	name, ok := doc.field("name")
+	if !ok || strings.TrimSpace(name) == "" {
+		c.report(SeverityError, 1, 1, "name-missing", "%s is missing a name", kind)
+		return "", false
+	}
+	if !namePattern.MatchString(name) {
+		c.atValue(doc, "name", SeverityError, "name-format", "name %q must use lowercase letters, digits and hyphens", name)
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		c.atValue(doc, "name", SeverityError, "name-length", "name is longer than %d characters", maxNameLength)
+	}
+	return name, true
+}
+
+func (c *checker) checkDescription(doc *document, required bool, lengthSeverity Severity) {
+	description, ok := doc.field("description")
+	if !ok || strings.TrimSpace(description) == "" {
+		if required {
+			c.report(SeverityError, 1, 1, "description-missing", "description is required; Claude uses it to decide when to use this")
+		}
+		return
+	}
+	if n := utf8.RuneCountInString(description); n > maxDescriptionLength {
+		c.atValue(doc, "description", lengthSeverity, "description-length", "description is %d characters; keep it under %d", n, maxDescriptionLength)
+	}
+}
+
+func (c *checker) checkBuiltinCollision(doc *document, key, name string) {
+	for _, builtin := range domain.BuiltinSlashCommands {
+		if name != builtin {
+			continue
+		}
+		message := "/%s is a built-in command and takes precedence over this one"
+		if key == "" {
+			c.report(SeverityWarning, 0, 0, "builtin-collision", message, name)
+		} else {
+			c.atValue(doc, key, SeverityWarning, "builtin-collision", message, name)
+		}
+		return
+	}
+}
+
+// checkTools checks an allowed-tools or tools list and returns how many
+// entries it has
+func (c *checker) checkTools(doc *document, key string) int {
+	node, ok := doc.values[key]
+	if !ok {
+		return 0
+	}
+
+	type entry struct {
+		text      string
+		line, col int
+	}
+	var entries []entry
+	switch node.Kind {
+	case yaml.ScalarNode:
+		offset := 0
+		if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+			offset = 1
+		}
+		for _, text := range domain.SplitToolList(node.Value) {
+			col := node.Column
+			if node.Line > 0 && !strings.Contains(node.Value, "\n") {
+				col += offset + strings.Index(node.Value, text)
+			}
+			entries = append(entries, entry{text, fileLine(node.Line), col})
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				c.report(SeverityError, fileLine(item.Line), item.Column, "tools-invalid", "%s entries must be strings", key)
+				continue
+			}
+			entries = append(entries, entry{item.Value, fileLine(item.Line), item.Column})
+		}
+	default:
+		c.atValue(doc, key, SeverityError, "tools-invalid", "%s must be a comma-separated string or a list", key)
+		return 1
+	}
+
+	for _, e := range entries {
+		rule, ok := domain.ParseToolRule(e.text)
+		if !ok {
+			c.report(SeverityError, e.line, e.col, "tools-invalid", "malformed %s entry %q", key, e.text)
+			continue
+		}
+		err := domain.CheckTool(rule.Tool, c.servers)
+		switch {
+		case errors.Is(err, domain.ErrUnknownMCPServer):
+			c.report(SeverityWarning, e.line, e.col, "unknown-mcp-server", "%s: %v", key, err)
+		case err != nil:
+			c.report(SeverityWarning, e.line, e.col, "unknown-tool", "%s: %v", key, err)
+		}
+	}
+	return len(entries)
+}
+
+func (c *checker) checkModel(doc *document, agent bool) {
+	model, ok := doc.field("model")
+	if !ok || model == "" || strings.HasPrefix(model, "claude-") {
+		return
+	}
+	for _, alias := range domain.ModelAliases {
+		if model == alias && (agent || alias != "inherit") {
+			return
+		}
+	}
+	aliases := "sonnet, opus, haiku, opusplan"
+	if agent {
+		aliases += ", inherit"
+	}
+	c.atValue(doc, "model", SeverityWarning, "unknown-model", "unknown model %q; use %s or a full claude-* model ID", model, aliases)
+}
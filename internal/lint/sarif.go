@@ -0,0 +1,118 @@
+package lint
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+)
+
+// Rules describes every rule, for SARIF consumers and --help output
+var Rules = map[string]string{
+	"frontmatter-invalid": "Frontmatter is not valid YAML",
+	"frontmatter-missing": "Skills and agents need frontmatter",
+	"name-missing":        "Skills and agents need a name",
+	"name-format":         "Names use lowercase letters, digits and hyphens",
+	"name-length":         "Names are at most 64 characters",
+	"name-mismatch":       "A skill's name matches its directory",
+	"description-missing": "Skills and agents need a description",
+	"description-length":  "Descriptions are at most 1024 characters",
+	"builtin-collision":   "Name is shadowed by a built-in slash command",
+	"tools-invalid":       "allowed-tools or tools entry is malformed",
+	"unknown-tool":        "allowed-tools or tools names an unknown tool",
+	"unknown-mcp-server":  "A tools entry names an MCP server that is not configured",
+	"tools-inherited":     "Agent has no tools list and inherits every tool",
+	"unknown-model":       "model is not a known alias or model ID",
+	"empty-body":          "File has no instructions after the frontmatter",
+	"skill-missing-file":  "Skill directory has no SKILL.md",
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifact `json:"artifactLocation"`
+	Region           *sarifRegion  `json:"region,omitempty"`
+}
+
+type sarifArtifact struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// SARIF renders the report as a SARIF 2.1.0 log for code scanning tools
+func (r *Report) SARIF(toolVersion string) ([]byte, error) {
+	ids := make([]string, 0, len(Rules))
+	for id := range Rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	driver := sarifDriver{Name: "claudectl", Version: toolVersion}
+	for _, id := range ids {
+		driver.Rules = append(driver.Rules, sarifRule{ID: id, ShortDescription: sarifMessage{Rules[id]}})
+	}
+
+	results := []sarifResult{}
+	for _, d := range r.Diagnostics {
+		location := sarifPhysicalLocation{ArtifactLocation: sarifArtifact{URI: filepath.ToSlash(d.File)}}
+		if d.Line > 0 {
+			location.Region = &sarifRegion{StartLine: d.Line, StartColumn: d.Column}
+		}
+		results = append(results, sarifResult{
+			RuleID:    d.Rule,
+			Level:     string(d.Severity),
+			Message:   sarifMessage{d.Message},
+			Locations: []sarifLocation{{PhysicalLocation: location}},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+	return json.MarshalIndent(log, "", "  ")
+}
@@ -11,12 +11,13 @@ import (
 )
 
 type AgentLoader struct {
-	logger *slog.Logger
+	logger   *slog.Logger
+	problems *Problems
 }
 
-func NewAgentLoader(logger *utils.Logger) Loader[domain.Agent] {
+func NewAgentLoader(logger *utils.Logger, problems *Problems) Loader[domain.Agent] {
 	logger.Debug("initializing agent loader")
-	return &AgentLoader{logger: logger.Logger}
+	return &AgentLoader{logger: logger.Logger, problems: problems}
 }
 
 func (a *AgentLoader) Load(scope domain.CapabilityScope) ([]domain.Agent, error) {
@@ -25,8 +26,11 @@ func (a *AgentLoader) Load(scope domain.CapabilityScope) ([]domain.Agent, error)
 		return nil, err
 	}
 
-	dir := filepath.Join(basePath, "agents")
+	return a.loadDir(filepath.Join(basePath, "agents"), scope)
+}
 
+// loadDir loads the agents in dir, which may also be a plugin's
+func (a *AgentLoader) loadDir(dir string, scope domain.CapabilityScope) ([]domain.Agent, error) {
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		a.logger.Debug("directory not found", "path", dir)
 		return []domain.Agent{}, nil
@@ -49,12 +53,14 @@ func (a *AgentLoader) Load(scope domain.CapabilityScope) ([]domain.Agent, error)
 		content, err := os.ReadFile(filePath)
 		if err != nil {
 			a.logger.Warn("failed to read file", "path", filePath, "error", err)
+			a.problems.Add("reading %s: %v", filePath, err)
 			continue
 		}
 
 		metadata, body, err := parseMarkdownWithFrontmatter(content)
 		if err != nil {
 			a.logger.Warn("failed to parse frontmatter", "path", filePath, "error", err)
+			a.problems.Add("parsing frontmatter in %s: %v", filePath, err)
 		}
 
 		name := strings.TrimSuffix(entry.Name(), ".md")
@@ -63,8 +69,10 @@ func (a *AgentLoader) Load(scope domain.CapabilityScope) ([]domain.Agent, error)
 		}
 
 		description := ""
+		var tools []string
 		if metadata != nil {
 			description = metadata.Description
+			tools = metadata.Tools
 		}
 
 		agent := domain.NewAgent(domain.AgentParams{
@@ -73,6 +81,7 @@ func (a *AgentLoader) Load(scope domain.CapabilityScope) ([]domain.Agent, error)
 			FilePath:    filePath,
 			Content:     string(body),
 			Scope:       scope,
+			Tools:       tools,
 		})
 		agents = append(agents, *agent)
 		a.logger.Debug("discovered agent", "name", name, "scope", scope)
@@ -1,6 +1,8 @@
 package loaders
 
 import (
+	"bytes"
+	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -11,12 +13,13 @@ import (
 )
 
 type CommandLoader struct {
-	logger *slog.Logger
+	logger   *slog.Logger
+	problems *Problems
 }
 
-func NewCommandLoader(logger *utils.Logger) Loader[domain.Command] {
+func NewCommandLoader(logger *utils.Logger, problems *Problems) Loader[domain.Command] {
 	logger.Debug("initializing command loader")
-	return &CommandLoader{logger: logger.Logger}
+	return &CommandLoader{logger: logger.Logger, problems: problems}
 }
 
 func (c *CommandLoader) Load(scope domain.CapabilityScope) ([]domain.Command, error) {
@@ -25,36 +28,42 @@ func (c *CommandLoader) Load(scope domain.CapabilityScope) ([]domain.Command, er
 		return nil, err
 	}
 
-	dir := filepath.Join(basePath, "commands")
+	return c.loadDir(filepath.Join(basePath, "commands"), scope)
+}
 
+// loadDir loads the commands in dir, which may also be a plugin's
+func (c *CommandLoader) loadDir(dir string, scope domain.CapabilityScope) ([]domain.Command, error) {
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		c.logger.Debug("directory not found", "path", dir)
 		return []domain.Command{}, nil
 	}
 
-	entries, err := os.ReadDir(dir)
-	if err != nil {
-		c.logger.Error("failed to read directory", "path", dir, "error", err)
-		return nil, err
-	}
-
+	// Subdirectories are namespaces: commands/<ns>/<name>.md
 	var commands []domain.Command
-	for _, entry := range entries {
+	err := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			c.logger.Warn("failed to read directory", "path", filePath, "error", err)
+			if filePath == dir {
+				return err
+			}
+			c.problems.Add("reading %s: %v", filePath, err)
+			return nil
+		}
 		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
-			continue
+			return nil
 		}
 
-		filePath := filepath.Join(dir, entry.Name())
-
 		content, err := os.ReadFile(filePath)
 		if err != nil {
 			c.logger.Warn("failed to read file", "path", filePath, "error", err)
-			continue
+			c.problems.Add("reading %s: %v", filePath, err)
+			return nil
 		}
 
 		metadata, body, err := parseMarkdownWithFrontmatter(content)
 		if err != nil {
 			c.logger.Warn("failed to parse frontmatter", "path", filePath, "error", err)
+			c.problems.Add("parsing frontmatter in %s: %v", filePath, err)
 		}
 
 		name := strings.TrimSuffix(entry.Name(), ".md")
@@ -62,22 +71,72 @@ func (c *CommandLoader) Load(scope domain.CapabilityScope) ([]domain.Command, er
 			name = metadata.Name
 		}
 
-		description := ""
+		description, argumentHint := "", ""
+		var allowedTools []string
 		if metadata != nil {
 			description = metadata.Description
+			argumentHint = metadata.ArgumentHint
+			allowedTools = metadata.AllowedTools
+		}
+
+		namespace := ""
+		if rel, err := filepath.Rel(dir, filepath.Dir(filePath)); err == nil && rel != "." {
+			namespace = strings.ReplaceAll(filepath.ToSlash(rel), "/", ":")
+		}
+
+		// Report lines of the file, not of the body
+		offset := bytes.Count(content, []byte("\n")) - bytes.Count(body, []byte("\n"))
+		refs := domain.ExtractFileReferences(string(body))
+		for i := range refs {
+			refs[i].Line += offset
+			refs[i].Missing = !refs[i].Dynamic() && !referenceExists(refs[i].Path)
+		}
+		snippets := domain.ExtractShellSnippets(string(body))
+		for i := range snippets {
+			snippets[i].Line += offset
 		}
 
 		command := domain.NewCommand(domain.CommandParams{
-			Name:        name,
-			Description: description,
-			FilePath:    filePath,
-			Content:     string(body),
-			Scope:       scope,
+			Name:           name,
+			Description:    description,
+			Namespace:      namespace,
+			FilePath:       filePath,
+			Content:        string(body),
+			Scope:          scope,
+			ArgumentHint:   argumentHint,
+			AllowedTools:   allowedTools,
+			FileReferences: refs,
+			ShellSnippets:  snippets,
 		})
 		commands = append(commands, *command)
-		c.logger.Debug("discovered command", "name", name, "scope", scope)
+		c.logger.Debug("discovered command", "name", command.QualifiedName(), "scope", scope)
+		return nil
+	})
+	if err != nil {
+		c.logger.Error("failed to read directory", "path", dir, "error", err)
+		return nil, err
 	}
 
 	c.logger.Info("discovered commands", "count", len(commands), "path", dir)
 	return commands, nil
 }
+
+// referenceExists resolves an @path the way Claude Code does: relative to
+// the project root, with ~ for the home directory
+func referenceExists(path string) bool {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return false
+		}
+		path = filepath.Join(home, rest)
+	} else if !filepath.IsAbs(path) {
+		root, err := utils.GetProjectRoot()
+		if err != nil {
+			return false
+		}
+		path = filepath.Join(root, path)
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
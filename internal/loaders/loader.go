@@ -2,6 +2,9 @@ package loaders
 
 import (
 	"bytes"
+	"errors"
+	"strconv"
+	"strings"
 
 	"claudectl/internal/domain"
 )
@@ -11,8 +14,11 @@ type Loader[T any] interface {
 }
 
 type MarkdownMetadata struct {
-	Name        string `yaml:"name"`
-	Description string `yaml:"description"`
+	Name         string   `yaml:"name"`
+	Description  string   `yaml:"description"`
+	ArgumentHint string   `yaml:"argument-hint"`
+	AllowedTools []string `yaml:"allowed-tools"`
+	Tools        []string `yaml:"tools"`
 }
 
 func hasYAMLFrontmatter(content []byte) bool {
@@ -44,9 +50,16 @@ func extractYAMLFrontmatterAndContent(content []byte) ([]byte, []byte) {
 	return frontmatter, body
 }
 
+// errUnclosedFrontmatter is returned for a file whose frontmatter has no
+// closing ---; the whole file is then treated as the body
+var errUnclosedFrontmatter = errors.New("frontmatter is not closed with ---")
+
 func parseMarkdownWithFrontmatter(content []byte) (*MarkdownMetadata, []byte, error) {
 	frontmatter, contentBody := extractYAMLFrontmatterAndContent(content)
 	if frontmatter == nil {
+		if hasYAMLFrontmatter(content) {
+			return nil, contentBody, errUnclosedFrontmatter
+		}
 		return nil, contentBody, nil
 	}
 
@@ -60,6 +73,7 @@ func parseMarkdownWithFrontmatter(content []byte) (*MarkdownMetadata, []byte, er
 func parseKeyValue(data []byte) MarkdownMetadata {
 	var metadata MarkdownMetadata
 	lines := bytes.Split(data, []byte("\n"))
+	key := ""
 
 	for _, line := range lines {
 		line = bytes.TrimSpace(line)
@@ -67,22 +81,71 @@ func parseKeyValue(data []byte) MarkdownMetadata {
 			continue
 		}
 
+		// Block list items belong to the last key
+		if item, ok := bytes.CutPrefix(line, []byte("- ")); ok {
+			tool := unquote(string(bytes.TrimSpace(item)))
+			switch key {
+			case "allowed-tools":
+				metadata.AllowedTools = append(metadata.AllowedTools, tool)
+			case "tools":
+				metadata.Tools = append(metadata.Tools, tool)
+			}
+			continue
+		}
+
 		// Find first colon
 		colonIdx := bytes.IndexByte(line, ':')
 		if colonIdx == -1 {
 			continue
 		}
 
-		key := string(bytes.TrimSpace(line[:colonIdx]))
-		value := string(bytes.TrimSpace(line[colonIdx+1:]))
+		key = string(bytes.TrimSpace(line[:colonIdx]))
+		value := unquote(string(bytes.TrimSpace(line[colonIdx+1:])))
 
 		switch key {
 		case "name":
 			metadata.Name = value
 		case "description":
 			metadata.Description = value
+		case "argument-hint":
+			metadata.ArgumentHint = value
+		case "allowed-tools":
+			metadata.AllowedTools = parseToolList(value)
+		case "tools":
+			metadata.Tools = parseToolList(value)
 		}
 	}
 
 	return metadata
 }
+
+// parseToolList reads a comma-separated or [flow, list] tools value
+func parseToolList(value string) []string {
+	if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+		value = value[1 : len(value)-1]
+	}
+	tools := domain.SplitToolList(value)
+	for i, tool := range tools {
+		tools[i] = unquote(tool)
+	}
+	return tools
+}
+
+// unquote strips the quotes around a quoted YAML scalar
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		if value[0] == '"' {
+			if s, err := strconv.Unquote(value); err == nil {
+				return s
+			}
+		}
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+	return value
+}
+
+// SplitFrontmatter returns the YAML frontmatter of a markdown file and the
+// body after it. Frontmatter is nil when the file has none.
+func SplitFrontmatter(content []byte) ([]byte, []byte) {
+	return extractYAMLFrontmatterAndContent(content)
+}
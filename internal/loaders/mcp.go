@@ -2,10 +2,12 @@ package loaders
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"os"
 
 	"claudectl/internal/domain"
+	"claudectl/internal/jsonedit"
 	"claudectl/internal/utils"
 )
 
@@ -22,31 +24,43 @@ func NewMCPLoader(logger *utils.Logger) MCPLoader {
 	return &mcpLoaderImpl{logger: logger.Logger}
 }
 
-// MCPServerConfig represents the structure of MCP server configuration
-type MCPServerConfig struct {
-	MCPType string            `json:"type"`
-	Command string            `json:"command,omitempty"`
-	Args    []string          `json:"args,omitempty"`
-	Env     map[string]string `json:"env,omitempty"`
-	Url     string            `json:"url,omitempty"`
-}
-
-// ClaudeConfig represents the structure of .claude.json or .mcp.json
-type ClaudeConfig struct {
-	MCPServers map[string]MCPServerConfig `json:"mcpServers"`
+// MCPConfigLocation returns the config file holding a scope's MCP servers
+// and the JSON path of the server map inside it
+func MCPConfigLocation(scope domain.CapabilityScope) (string, []string, error) {
+	switch scope {
+	case domain.ScopeUser:
+		path, err := utils.GetUserConfigFile()
+		return path, []string{"mcpServers"}, err
+	case domain.ScopeLocal:
+		path, err := utils.GetUserConfigFile()
+		if err != nil {
+			return "", nil, err
+		}
+		root, err := utils.GetProjectRoot()
+		return path, []string{"projects", root, "mcpServers"}, err
+	default:
+		path, err := utils.GetProjectMCPConfigFile()
+		return path, []string{"mcpServers"}, err
+	}
 }
 
+// Load returns the servers of a scope. Local servers are private to the
+// current project, so they are included when loading the project scope.
 func (m *mcpLoaderImpl) Load(scope domain.CapabilityScope) ([]domain.MCPServer, error) {
-	// Get config path based on scope
-	var pathGetter func() (string, error)
+	servers, err := m.loadScope(scope)
+	if err != nil || scope != domain.ScopeProject {
+		return servers, err
+	}
 
-	if scope == domain.ScopeUser {
-		pathGetter = utils.GetUserConfigFile
-	} else {
-		pathGetter = utils.GetProjectMCPConfigFile
+	local, err := m.loadScope(domain.ScopeLocal)
+	if err != nil {
+		return nil, err
 	}
+	return append(servers, local...), nil
+}
 
-	configPath, err := pathGetter()
+func (m *mcpLoaderImpl) loadScope(scope domain.CapabilityScope) ([]domain.MCPServer, error) {
+	configPath, serversPath, err := MCPConfigLocation(scope)
 	if err != nil {
 		m.logger.Error("failed to get config path", "scope", scope, "error", err)
 		return nil, err
@@ -65,29 +79,50 @@ func (m *mcpLoaderImpl) Load(scope domain.CapabilityScope) ([]domain.MCPServer,
 		return nil, err
 	}
 
-	// Parse JSON
-	var config ClaudeConfig
-	if err := json.Unmarshal(data, &config); err != nil {
+	servers, err := ParseMCPServers(data, serversPath, scope)
+	if err != nil {
 		m.logger.Error("failed to parse config JSON", "path", configPath, "error", err)
 		return nil, err
 	}
 
-	// Convert to domain MCPServer models
-	var capabilities []domain.MCPServer
-	for name, serverConfig := range config.MCPServers {
-		server := *domain.NewMCPServer(domain.MCPServerParams{
-			Name:    name,
-			Scope:   scope,
-			Command: serverConfig.Command,
-			Args:    serverConfig.Args,
-			Env:     serverConfig.Env,
-			MCPType: serverConfig.MCPType,
-			Url:     serverConfig.Url,
-		})
-		capabilities = append(capabilities, server)
-		m.logger.Debug("loaded MCP server", "name", name, "scope", scope)
+	for i := range servers {
+		servers[i].FilePath = configPath
+		m.logger.Debug("loaded MCP server", "name", servers[i].Name, "scope", scope)
+	}
+
+	m.logger.Info("loaded MCP servers (domain)", "count", len(servers), "scope", scope, "path", configPath)
+	return servers, nil
+}
+
+// ParseMCPServers reads the server map at serversPath, keeping the order
+// the servers appear in the file
+func ParseMCPServers(data []byte, serversPath []string, scope domain.CapabilityScope) ([]domain.MCPServer, error) {
+	names, err := jsonedit.Keys(data, serversPath...)
+	if errors.Is(err, jsonedit.ErrNotFound) {
+		return []domain.MCPServer{}, nil
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	m.logger.Info("loaded MCP servers (domain)", "count", len(capabilities), "scope", scope, "path", configPath)
+	raw, err := jsonedit.Find(data, serversPath...)
+	if err != nil {
+		return nil, err
+	}
+
+	var configs map[string]MCPServerConfig
+	if err := json.Unmarshal(raw, &configs); err != nil {
+		return nil, err
+	}
+
+	// Convert to domain MCPServer models
+	capabilities := make([]domain.MCPServer, 0, len(names))
+	for _, name := range names {
+		server := configs[name].ToDomain(name, scope)
+		if offset, err := jsonedit.Offset(data, append(serversPath, name)...); err == nil {
+			server.Line = jsonedit.Line(data, offset)
+		}
+		capabilities = append(capabilities, *server)
+	}
 	return capabilities, nil
 }
@@ -0,0 +1,130 @@
+package loaders
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"claudectl/internal/domain"
+)
+
+// MCPServerConfig represents the structure of MCP server configuration.
+// Fields claudectl doesn't model are kept in Extra so they survive a round trip.
+type MCPServerConfig struct {
+	MCPType       string            `json:"type,omitempty"`
+	Command       string            `json:"command,omitempty"`
+	Args          []string          `json:"args,omitempty"`
+	Env           map[string]string `json:"env,omitempty"`
+	Url           string            `json:"url,omitempty"`
+	Headers       map[string]string `json:"headers,omitempty"`
+	HeadersHelper string            `json:"headersHelper,omitempty"`
+	Timeout       int               `json:"timeout,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// mcpServerConfigFields is an alias without the custom (un)marshalers
+type mcpServerConfigFields MCPServerConfig
+
+var knownMCPConfigKeys = map[string]bool{
+	"type":          true,
+	"command":       true,
+	"args":          true,
+	"env":           true,
+	"url":           true,
+	"headers":       true,
+	"headersHelper": true,
+	"timeout":       true,
+}
+
+func (c *MCPServerConfig) UnmarshalJSON(data []byte) error {
+	var fields mcpServerConfigFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	for key, value := range raw {
+		if knownMCPConfigKeys[key] {
+			continue
+		}
+		if fields.Extra == nil {
+			fields.Extra = map[string]json.RawMessage{}
+		}
+		fields.Extra[key] = value
+	}
+
+	*c = MCPServerConfig(fields)
+	return nil
+}
+
+func (c MCPServerConfig) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(mcpServerConfigFields(c))
+	if err != nil {
+		return nil, err
+	}
+	if len(c.Extra) == 0 {
+		return data, nil
+	}
+
+	keys := make([]string, 0, len(c.Extra))
+	for key := range c.Extra {
+		if !knownMCPConfigKeys[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var b bytes.Buffer
+	b.Write(data[:len(data)-1])
+	for _, key := range keys {
+		if b.Len() > 1 {
+			b.WriteByte(',')
+		}
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(encodedKey)
+		b.WriteByte(':')
+		b.Write(c.Extra[key])
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// ToDomain converts the config entry into a domain MCPServer
+func (c MCPServerConfig) ToDomain(name string, scope domain.CapabilityScope) *domain.MCPServer {
+	return domain.NewMCPServer(domain.MCPServerParams{
+		Name:          name,
+		Scope:         scope,
+		Command:       c.Command,
+		Args:          c.Args,
+		Env:           c.Env,
+		MCPType:       c.MCPType,
+		Url:           c.Url,
+		Headers:       c.Headers,
+		HeadersHelper: c.HeadersHelper,
+		Timeout:       c.Timeout,
+		Extra:         c.Extra,
+	})
+}
+
+// NewMCPServerConfig converts a domain MCPServer back into its config entry
+func NewMCPServerConfig(server *domain.MCPServer) MCPServerConfig {
+	return MCPServerConfig{
+		MCPType:       server.MCPType,
+		Command:       server.Command,
+		Args:          server.Args,
+		Env:           server.Env,
+		Url:           server.Url,
+		Headers:       server.Headers,
+		HeadersHelper: server.HeadersHelper,
+		Timeout:       server.Timeout,
+		Extra:         server.Extra,
+	}
+}
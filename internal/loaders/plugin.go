@@ -11,13 +11,15 @@ import (
 )
 
 type PluginLoader struct {
-	logger *slog.Logger
+	logger   *slog.Logger
+	problems *Problems
 }
 
-func NewPluginLoader(logger *utils.Logger) Loader[domain.Plugin] {
+func NewPluginLoader(logger *utils.Logger, problems *Problems) Loader[domain.Plugin] {
 	logger.Debug("initializing plugin loader")
 	return &PluginLoader{
-		logger: logger.Logger,
+		logger:   logger.Logger,
+		problems: problems,
 	}
 }
 
@@ -74,6 +76,7 @@ func (p *PluginLoader) Load(scope domain.CapabilityScope) ([]domain.Plugin, erro
 			plugin, err := p.loadPluginFromPathDomain(installation.InstallPath, installation.Version, scope, pluginKey)
 			if err != nil {
 				p.logger.Warn("failed to load plugin", "key", pluginKey, "path", installation.InstallPath, "error", err)
+				p.problems.Add("loading plugin %s: %v", pluginKey, err)
 				continue
 			}
 
@@ -105,9 +108,14 @@ func (p *PluginLoader) loadInstalledPluginsRegistryDomain(registryPath string) (
 	return &registry, nil
 }
 
+// PluginManifestPath returns where a plugin's manifest lives
+func PluginManifestPath(installPath string) string {
+	return filepath.Join(installPath, ".claude-plugin", "plugin.json")
+}
+
 // loadPluginFromPathDomain loads a plugin from the given installation path (domain model)
 func (p *PluginLoader) loadPluginFromPathDomain(installPath, version string, scope domain.CapabilityScope, pluginKey string) (*domain.Plugin, error) {
-	manifestPath := filepath.Join(installPath, ".claude-plugin", "plugin.json")
+	manifestPath := PluginManifestPath(installPath)
 
 	// Try to load manifest if it exists
 	var manifest PluginManifest
@@ -161,7 +169,11 @@ func (p *PluginLoader) loadPluginFromPathDomain(installPath, version string, sco
 		License:    manifest.License,
 		Keywords:   manifest.Keywords,
 		Path:       installPath,
+		Key:        pluginKey,
 	}
+	plugin.LatestVersion = p.latestVersion(pluginKey)
+
+	p.loadContents(plugin)
 
 	if hasManifest {
 		p.logger.Debug("loaded plugin with manifest (domain)", "name", manifest.Name, "version", version, "scope", scope)
@@ -0,0 +1,49 @@
+package loaders
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"claudectl/internal/domain"
+)
+
+// loadContents fills in the commands, skills, agents and MCP servers a
+// plugin ships in its install directory. They take the plugin's scope.
+func (p *PluginLoader) loadContents(plugin *domain.Plugin) {
+	dir := plugin.Path
+	var err error
+
+	commands := &CommandLoader{logger: p.logger, problems: p.problems}
+	if plugin.Commands, err = commands.loadDir(filepath.Join(dir, "commands"), plugin.Scope); err != nil {
+		p.logger.Warn("failed to load plugin commands", "plugin", plugin.Name, "error", err)
+		p.problems.Add("loading commands of plugin %s: %v", plugin.Name, err)
+	}
+	skills := &SkillLoader{logger: p.logger, problems: p.problems}
+	if plugin.Skills, err = skills.loadDir(filepath.Join(dir, "skills"), plugin.Scope); err != nil {
+		p.logger.Warn("failed to load plugin skills", "plugin", plugin.Name, "error", err)
+		p.problems.Add("loading skills of plugin %s: %v", plugin.Name, err)
+	}
+	agents := &AgentLoader{logger: p.logger, problems: p.problems}
+	if plugin.Agents, err = agents.loadDir(filepath.Join(dir, "agents"), plugin.Scope); err != nil {
+		p.logger.Warn("failed to load plugin agents", "plugin", plugin.Name, "error", err)
+		p.problems.Add("loading agents of plugin %s: %v", plugin.Name, err)
+	}
+
+	configPath := filepath.Join(dir, ".mcp.json")
+	data, err := os.ReadFile(configPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	if err == nil {
+		plugin.MCPServers, err = ParseMCPServers(data, []string{"mcpServers"}, plugin.Scope)
+	}
+	if err != nil {
+		p.logger.Warn("failed to load plugin MCP servers", "plugin", plugin.Name, "path", configPath, "error", err)
+		p.problems.Add("reading %s: %v", configPath, err)
+		return
+	}
+	for i := range plugin.MCPServers {
+		plugin.MCPServers[i].FilePath = configPath
+	}
+}
@@ -0,0 +1,63 @@
+package loaders
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"claudectl/internal/utils"
+)
+
+// MarketplaceManifest is the part of a marketplace's
+// .claude-plugin/marketplace.json that lists its plugins
+type MarketplaceManifest struct {
+	Name    string `json:"name"`
+	Plugins []struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"plugins"`
+}
+
+// MarketplaceManifestPath returns where a marketplace added to Claude Code
+// keeps its manifest
+func MarketplaceManifestPath(marketplace string) (string, error) {
+	pluginsDir, err := utils.GetUserPluginsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(pluginsDir, "marketplaces", marketplace, ".claude-plugin", "marketplace.json"), nil
+}
+
+// latestVersion returns the version of a plugin its marketplace offers, or
+// "" when the marketplace is not cloned locally or does not version it
+func (p *PluginLoader) latestVersion(pluginKey string) string {
+	name, marketplace, ok := strings.Cut(pluginKey, "@")
+	if !ok {
+		return ""
+	}
+	path, err := MarketplaceManifestPath(marketplace)
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ""
+	}
+	var manifest MarketplaceManifest
+	if err == nil {
+		err = json.Unmarshal(data, &manifest)
+	}
+	if err != nil {
+		p.logger.Warn("failed to read marketplace manifest", "path", path, "error", err)
+		p.problems.Add("reading marketplace manifest %s: %v", path, err)
+		return ""
+	}
+	for _, plugin := range manifest.Plugins {
+		if plugin.Name == name {
+			return plugin.Version
+		}
+	}
+	return ""
+}
@@ -0,0 +1,53 @@
+package loaders
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Problems collects the files loaders skip or only partly read, such as
+// unreadable files, broken frontmatter and bad plugin manifests. Loads
+// keep going past them, so this is where callers learn about them. A nil
+// Problems records nothing.
+type Problems struct {
+	mu       sync.Mutex
+	problems []string
+}
+
+func NewProblems() *Problems {
+	return &Problems{}
+}
+
+// Add records a problem once
+func (p *Problems) Add(format string, args ...any) {
+	if p == nil {
+		return
+	}
+	problem := fmt.Sprintf(format, args...)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !slices.Contains(p.problems, problem) {
+		p.problems = append(p.problems, problem)
+	}
+}
+
+// Reset forgets what earlier loads recorded
+func (p *Problems) Reset() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.problems = nil
+}
+
+// List returns the problems in the order they were recorded
+func (p *Problems) List() []string {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string{}, p.problems...)
+}
@@ -10,12 +10,13 @@ import (
 )
 
 type SkillLoader struct {
-	logger *slog.Logger
+	logger   *slog.Logger
+	problems *Problems
 }
 
-func NewSkillLoader(logger *utils.Logger) Loader[domain.Skill] {
+func NewSkillLoader(logger *utils.Logger, problems *Problems) Loader[domain.Skill] {
 	logger.Debug("initializing skill loader")
-	return &SkillLoader{logger: logger.Logger}
+	return &SkillLoader{logger: logger.Logger, problems: problems}
 }
 
 func (s *SkillLoader) Load(scope domain.CapabilityScope) ([]domain.Skill, error) {
@@ -24,8 +25,11 @@ func (s *SkillLoader) Load(scope domain.CapabilityScope) ([]domain.Skill, error)
 		return nil, err
 	}
 
-	dir := filepath.Join(basePath, "skills")
+	return s.loadDir(filepath.Join(basePath, "skills"), scope)
+}
 
+// loadDir loads the skills in dir, which may also be a plugin's
+func (s *SkillLoader) loadDir(dir string, scope domain.CapabilityScope) ([]domain.Skill, error) {
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		s.logger.Debug("directory not found", "path", dir)
 		return []domain.Skill{}, nil
@@ -54,12 +58,14 @@ func (s *SkillLoader) Load(scope domain.CapabilityScope) ([]domain.Skill, error)
 		content, err := os.ReadFile(skillFilePath)
 		if err != nil {
 			s.logger.Warn("failed to read file", "path", skillFilePath, "error", err)
+			s.problems.Add("reading %s: %v", skillFilePath, err)
 			continue
 		}
 
 		metadata, body, err := parseMarkdownWithFrontmatter(content)
 		if err != nil {
 			s.logger.Warn("failed to parse frontmatter", "path", skillFilePath, "error", err)
+			s.problems.Add("parsing frontmatter in %s: %v", skillFilePath, err)
 		}
 
 		name := skillDir
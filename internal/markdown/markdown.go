@@ -0,0 +1,103 @@
+// Package markdown renders capability files for the terminal
+package markdown
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
+	"gopkg.in/yaml.v3"
+)
+
+// Field is one top-level frontmatter key with its value as display text
+type Field struct {
+	Key   string
+	Value string
+}
+
+// Fields parses YAML frontmatter into its top-level fields, in file order
+func Fields(frontmatter []byte) ([]Field, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(frontmatter, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("frontmatter is not a mapping")
+	}
+
+	fields := make([]Field, 0, len(root.Content)/2)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		fields = append(fields, Field{Key: root.Content[i].Value, Value: display(root.Content[i+1])})
+	}
+	return fields, nil
+}
+
+// display flattens a YAML value to one line of text
+func display(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return strings.TrimSpace(node.Value)
+	case yaml.SequenceNode:
+		items := make([]string, len(node.Content))
+		for i, item := range node.Content {
+			items[i] = display(item)
+		}
+		return strings.Join(items, ", ")
+	case yaml.MappingNode:
+		var pairs []string
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			pairs = append(pairs, node.Content[i].Value+": "+display(node.Content[i+1]))
+		}
+		return "{" + strings.Join(pairs, ", ") + "}"
+	case yaml.AliasNode:
+		return display(node.Alias)
+	}
+	return ""
+}
+
+// Renderer renders markdown to styled terminal text, reusing the glamour
+// renderer and the last result while the width stays the same
+type Renderer struct {
+	width    int
+	renderer *glamour.TermRenderer
+	lastIn   string
+	lastOut  string
+}
+
+// Render renders text wrapped to width, with fenced code blocks highlighted
+func (r *Renderer) Render(text string, width int) (string, error) {
+	if r.renderer == nil || width != r.width {
+		renderer, err := newTermRenderer(width)
+		if err != nil {
+			return "", err
+		}
+		r.renderer, r.width, r.lastIn, r.lastOut = renderer, width, "", ""
+	}
+	if text == r.lastIn && r.lastOut != "" {
+		return r.lastOut, nil
+	}
+
+	out, err := r.renderer.Render(text)
+	if err != nil {
+		return "", err
+	}
+	out = strings.Trim(out, "\n")
+	r.lastIn, r.lastOut = text, out
+	return out, nil
+}
+
+// newTermRenderer uses the dark style to match the TUI, without the
+// document margin since the detail panel has its own padding
+func newTermRenderer(width int) (*glamour.TermRenderer, error) {
+	style := styles.DarkStyleConfig
+	margin := uint(0)
+	style.Document.Margin = &margin
+	style.Document.BlockPrefix = ""
+	style.Document.BlockSuffix = ""
+	return glamour.NewTermRenderer(glamour.WithStyles(style), glamour.WithWordWrap(width))
+}
@@ -0,0 +1,292 @@
+// Package mcpaudit checks whether stdio MCP servers can be launched and
+// whether the packages they launch are pinned to a version.
+package mcpaudit
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/utils"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Finding codes
+const (
+	CodeCommandNotFound = "command-not-found"
+	CodeMissingFile     = "missing-file"
+	CodeUnsetVariable   = "unset-variable"
+	CodeUnpinned        = "unpinned"
+)
+
+type Finding struct {
+	Severity Severity `json:"severity"`
+	Code     string   `json:"code"`
+	Message  string   `json:"message"`
+}
+
+// Package is the package reference a launcher such as npx installs
+type Package struct {
+	Launcher string `json:"launcher"`
+	Name     string `json:"name"`
+	Version  string `json:"version,omitempty"`
+	Pinned   bool   `json:"pinned"`
+}
+
+func (p Package) String() string {
+	switch {
+	case p.Version == "":
+		return p.Name
+	case p.Launcher == "docker" && !strings.HasPrefix(p.Version, "sha256:"):
+		return p.Name + ":" + p.Version
+	case strings.ContainsAny(p.Version[:1], "=<>!~"):
+		return p.Name + p.Version
+	default:
+		return p.Name + "@" + p.Version
+	}
+}
+
+type Report struct {
+	Server          string                 `json:"server"`
+	Scope           domain.CapabilityScope `json:"scope"`
+	Transport       domain.MCPTransport    `json:"transport"`
+	Command         string                 `json:"command,omitempty"`
+	ResolvedCommand string                 `json:"resolvedCommand,omitempty"`
+	Package         *Package               `json:"package,omitempty"`
+	Findings        []Finding              `json:"findings,omitempty"`
+}
+
+const (
+	StatusOK     = "ok"
+	StatusRemote = "remote"
+)
+
+// Status is the most severe finding, "ok" when there is none, or "remote"
+// for servers that are not launched locally
+func (r Report) Status() string {
+	if r.Transport != domain.TransportStdio {
+		return StatusRemote
+	}
+	status := StatusOK
+	for _, f := range r.Findings {
+		switch {
+		case f.Severity == SeverityError:
+			return string(SeverityError)
+		case f.Severity == SeverityWarning:
+			status = string(SeverityWarning)
+		}
+	}
+	return status
+}
+
+// Summary is a short label for the worst finding, suitable for a column
+func (r Report) Summary() string {
+	var worst *Finding
+	for i, f := range r.Findings {
+		if f.Severity == SeverityInfo {
+			continue
+		}
+		if worst == nil || (f.Severity == SeverityError && worst.Severity != SeverityError) {
+			worst = &r.Findings[i]
+		}
+	}
+	if worst == nil {
+		return r.Status()
+	}
+	return strings.ReplaceAll(worst.Code, "-", " ")
+}
+
+func (r *Report) add(severity Severity, code, format string, args ...any) {
+	r.Findings = append(r.Findings, Finding{Severity: severity, Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// Audit checks a single server. Only stdio servers are inspected.
+func Audit(server domain.MCPServer) Report {
+	report := Report{
+		Server:    server.Name,
+		Scope:     server.Scope,
+		Transport: server.Transport(),
+		Command:   server.Command,
+	}
+	if report.Transport != domain.TransportStdio || server.Command == "" {
+		return report
+	}
+
+	command := report.expand("command", server.Command)
+	args := make([]string, len(server.Args))
+	for i, arg := range server.Args {
+		args[i] = report.expand(fmt.Sprintf("args[%d]", i), arg)
+	}
+	for _, key := range sortedKeys(server.Env) {
+		report.expand("env."+key, server.Env[key])
+	}
+
+	report.resolveCommand(command)
+
+	launcher := launcherName(command)
+	switch launcher {
+	case "npx":
+		report.Package = npxPackage(args)
+	case "uvx":
+		report.Package = uvxPackage(args)
+	case "pipx":
+		report.Package = pipxPackage(args)
+	case "docker":
+		report.Package = dockerImage(args)
+	}
+	if report.Package != nil {
+		report.checkPinning()
+	}
+
+	// docker arguments are mostly container paths and mounts
+	if launcher != "docker" {
+		for _, arg := range args {
+			report.checkPath(arg)
+		}
+	}
+	return report
+}
+
+// AuditAll checks every server
+func AuditAll(servers []domain.MCPServer) []Report {
+	reports := make([]Report, len(servers))
+	for i, server := range servers {
+		reports[i] = Audit(server)
+	}
+	return reports
+}
+
+var variablePattern = regexp.MustCompile(`\$\{[A-Za-z_][A-Za-z0-9_]*(:-[^}]*)?\}`)
+
+// expand resolves ${VAR} and ${VAR:-default} the way Claude Code does when
+// it launches the server, recording variables that are not set
+func (r *Report) expand(field, s string) string {
+	expanded, missing := domain.ExpandVariables(s, os.LookupEnv)
+	for _, variable := range missing {
+		r.add(SeverityWarning, CodeUnsetVariable, "%s uses ${%s}, which is not set in this environment", field, variable)
+	}
+	return expanded
+}
+
+func (r *Report) resolveCommand(command string) {
+	if variablePattern.MatchString(command) {
+		return
+	}
+	if !strings.ContainsRune(command, filepath.Separator) {
+		path, err := exec.LookPath(command)
+		if err != nil {
+			r.add(SeverityError, CodeCommandNotFound, "%s was not found on PATH", command)
+			return
+		}
+		r.ResolvedCommand = path
+		return
+	}
+
+	path := resolvePath(command)
+	info, err := os.Stat(path)
+	switch {
+	case err != nil:
+		r.add(SeverityError, CodeCommandNotFound, "%s does not exist", command)
+	case info.IsDir() || info.Mode()&0111 == 0:
+		r.add(SeverityError, CodeCommandNotFound, "%s is not an executable file", command)
+	default:
+		r.ResolvedCommand = path
+	}
+}
+
+var scriptExtensions = map[string]bool{
+	".js": true, ".mjs": true, ".cjs": true, ".ts": true, ".py": true,
+	".jar": true, ".sh": true, ".rb": true, ".exe": true, ".dll": true,
+}
+
+// checkPath reports arguments that look like local paths but do not exist
+func (r *Report) checkPath(arg string) {
+	if strings.HasPrefix(arg, "-") {
+		_, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return
+		}
+		arg = value
+	}
+	if !looksLikePath(arg) || variablePattern.MatchString(arg) {
+		return
+	}
+	if _, err := os.Stat(resolvePath(arg)); errors.Is(err, fs.ErrNotExist) {
+		r.add(SeverityError, CodeMissingFile, "%s does not exist", arg)
+	}
+}
+
+func looksLikePath(arg string) bool {
+	for _, prefix := range []string{"/", "./", "../", "~/"} {
+		if strings.HasPrefix(arg, prefix) {
+			return true
+		}
+	}
+	if strings.HasPrefix(arg, "@") || strings.Contains(arg, "://") {
+		return false
+	}
+	return strings.Contains(arg, "/") && scriptExtensions[filepath.Ext(arg)]
+}
+
+// resolvePath makes a path absolute the way it is seen by a server started
+// in the project directory
+func resolvePath(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	if filepath.IsAbs(path) {
+		return path
+	}
+	if root, err := utils.GetProjectRoot(); err == nil {
+		return filepath.Join(root, path)
+	}
+	return path
+}
+
+func launcherName(command string) string {
+	name := filepath.Base(command)
+	for _, ext := range []string{".cmd", ".exe"} {
+		name = strings.TrimSuffix(name, ext)
+	}
+	return name
+}
+
+func (r *Report) checkPinning() {
+	p := r.Package
+	missing := "a version"
+	if p.Launcher == "docker" {
+		missing = "a tag or digest"
+	}
+	switch {
+	case p.Pinned:
+	case p.Version == "":
+		r.add(SeverityWarning, CodeUnpinned, "%s runs %s without %s; every start may pick up a new release", p.Launcher, p.Name, missing)
+	default:
+		r.add(SeverityWarning, CodeUnpinned, "%s runs %s at %q, which is not an exact version", p.Launcher, p.Name, p.Version)
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,194 @@
+package mcpaudit
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	exactSemver   = regexp.MustCompile(`^v?\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+	imageDigest   = regexp.MustCompile(`@sha256:[0-9a-f]{64}$`)
+	pythonVersion = regexp.MustCompile(`^(.+?)\s*(===?|~=|!=|>=|<=|>|<)\s*(.+)$`)
+)
+
+// splitLauncherArgs walks launcher options and returns the values of the
+// options in wanted plus the first positional argument. valueFlags lists
+// the options that take a separate value.
+func splitLauncherArgs(args []string, valueFlags map[string]bool, wanted ...string) (map[string][]string, string) {
+	values := map[string][]string{}
+	isWanted := func(flag string) bool {
+		for _, w := range wanted {
+			if w == flag {
+				return true
+			}
+		}
+		return false
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			if i+1 < len(args) {
+				return values, args[i+1]
+			}
+			return values, ""
+		}
+		if !strings.HasPrefix(arg, "-") {
+			return values, arg
+		}
+		if flag, value, ok := strings.Cut(arg, "="); ok {
+			if isWanted(flag) {
+				values[flag] = append(values[flag], value)
+			}
+			continue
+		}
+		if valueFlags[arg] && i+1 < len(args) {
+			if isWanted(arg) {
+				values[arg] = append(values[arg], args[i+1])
+			}
+			i++
+		}
+	}
+	return values, ""
+}
+
+var npxValueFlags = map[string]bool{
+	"-p": true, "--package": true, "-c": true, "--call": true,
+	"--registry": true, "--cache": true, "--userconfig": true, "-w": true, "--workspace": true,
+}
+
+// npxPackage extracts the package from `npx [options] <pkg>[@version]`
+func npxPackage(args []string) *Package {
+	values, positional := splitLauncherArgs(args, npxValueFlags, "-p", "--package")
+	spec := positional
+	if packages := append(values["-p"], values["--package"]...); len(packages) > 0 {
+		spec = packages[0]
+	}
+	if spec == "" {
+		return nil
+	}
+
+	if isNonRegistryNPMSpec(spec) {
+		return &Package{Launcher: "npx", Name: spec, Pinned: strings.Contains(spec, "#")}
+	}
+
+	name, version := spec, ""
+	if at := strings.LastIndex(spec, "@"); at > 0 {
+		name, version = spec[:at], spec[at+1:]
+	}
+	return &Package{Launcher: "npx", Name: name, Version: version, Pinned: exactSemver.MatchString(version)}
+}
+
+func isNonRegistryNPMSpec(spec string) bool {
+	for _, prefix := range []string{"git+", "git:", "github:", "gitlab:", "bitbucket:", "http:", "https:", "file:", ".", "/"} {
+		if strings.HasPrefix(spec, prefix) {
+			return true
+		}
+	}
+	// user/repo is GitHub shorthand; scoped packages start with @
+	return !strings.HasPrefix(spec, "@") && strings.Contains(spec, "/")
+}
+
+var uvxValueFlags = map[string]bool{
+	"--from": true, "--with": true, "-w": true, "--with-editable": true, "--with-requirements": true,
+	"-p": true, "--python": true, "--index": true, "--index-url": true, "--extra-index-url": true,
+	"--default-index": true, "--directory": true, "--project": true, "--config-file": true, "--cache-dir": true,
+}
+
+// uvxPackage extracts the package from `uvx [--from spec] <tool>[@version]`
+func uvxPackage(args []string) *Package {
+	values, positional := splitLauncherArgs(args, uvxValueFlags, "--from")
+	spec := positional
+	if from := values["--from"]; len(from) > 0 {
+		spec = from[0]
+	}
+	if spec == "" {
+		return nil
+	}
+	return pythonPackage("uvx", spec)
+}
+
+var pipxValueFlags = map[string]bool{
+	"--spec": true, "--python": true, "--index-url": true, "--pip-args": true,
+}
+
+// pipxPackage extracts the package from `pipx run [--spec spec] <app>`
+func pipxPackage(args []string) *Package {
+	if len(args) == 0 || args[0] != "run" {
+		return nil
+	}
+	values, positional := splitLauncherArgs(args[1:], pipxValueFlags, "--spec")
+	spec := positional
+	if specs := values["--spec"]; len(specs) > 0 {
+		spec = specs[0]
+	}
+	if spec == "" {
+		return nil
+	}
+	return pythonPackage("pipx", spec)
+}
+
+// pythonPackage parses a requirement such as name==1.0, name@1.0 (uvx) or
+// a VCS/URL/path reference
+func pythonPackage(launcher, spec string) *Package {
+	if scheme, rest, ok := strings.Cut(spec, "://"); ok {
+		// git+https://host/repo@rev is pinned to rev
+		_, rev, hasRev := strings.Cut(rest, "@")
+		return &Package{Launcher: launcher, Name: scheme + "://" + rest, Pinned: strings.HasPrefix(scheme, "git+") && hasRev && rev != ""}
+	}
+	if strings.HasPrefix(spec, ".") || strings.HasPrefix(spec, "/") {
+		return &Package{Launcher: launcher, Name: spec, Pinned: true}
+	}
+
+	if name, version, ok := strings.Cut(spec, "@"); ok {
+		return &Package{Launcher: launcher, Name: name, Version: version, Pinned: exactSemver.MatchString(version) || isPlainVersion(version)}
+	}
+	if parts := pythonVersion.FindStringSubmatch(spec); parts != nil {
+		operator, version := parts[2], parts[3]
+		pinned := (operator == "==" || operator == "===") && !strings.ContainsAny(version, "*,")
+		return &Package{Launcher: launcher, Name: parts[1], Version: operator + version, Pinned: pinned}
+	}
+	return &Package{Launcher: launcher, Name: spec}
+}
+
+var plainVersion = regexp.MustCompile(`^\d+(\.\d+)*([a-z]+\d*)?$`)
+
+// isPlainVersion accepts PEP 440 style versions such as 2.1 or 1.0.0rc1
+func isPlainVersion(version string) bool {
+	return plainVersion.MatchString(version)
+}
+
+var dockerValueFlags = map[string]bool{
+	"-e": true, "--env": true, "--env-file": true, "-v": true, "--volume": true, "--mount": true,
+	"--name": true, "-p": true, "--publish": true, "--network": true, "--net": true, "-w": true,
+	"--workdir": true, "--entrypoint": true, "-u": true, "--user": true, "--platform": true,
+	"-l": true, "--label": true, "--pull": true, "-h": true, "--hostname": true, "-m": true,
+	"--memory": true, "--cpus": true, "--add-host": true, "--cap-add": true, "--cap-drop": true,
+	"--device": true, "--dns": true, "--tmpfs": true, "--ulimit": true, "--security-opt": true,
+	"--restart": true, "--log-driver": true, "--log-opt": true, "--gpus": true,
+}
+
+// dockerImage extracts the image from `docker [container] run [options] <image>`
+func dockerImage(args []string) *Package {
+	if len(args) > 0 && args[0] == "container" {
+		args = args[1:]
+	}
+	if len(args) == 0 || args[0] != "run" {
+		return nil
+	}
+	_, image := splitLauncherArgs(args[1:], dockerValueFlags)
+	if image == "" {
+		return nil
+	}
+
+	if imageDigest.MatchString(image) {
+		at := strings.LastIndex(image, "@")
+		return &Package{Launcher: "docker", Name: image[:at], Version: image[at+1:], Pinned: true}
+	}
+
+	name, tag := image, ""
+	if colon := strings.LastIndex(image, ":"); colon > strings.LastIndex(image, "/") {
+		name, tag = image[:colon], image[colon+1:]
+	}
+	return &Package{Launcher: "docker", Name: name, Version: tag, Pinned: tag != "" && tag != "latest"}
+}
@@ -0,0 +1,111 @@
+package mcpclient
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/tokens"
+)
+
+// BenchResult summarises repeated starts of one server
+type BenchResult struct {
+	Server            string                 `json:"server"`
+	Scope             domain.CapabilityScope `json:"scope"`
+	Transport         domain.MCPTransport    `json:"transport"`
+	ServerInfo        *Implementation        `json:"serverInfo,omitempty"`
+	Runs              int                    `json:"runs"`
+	Startup           []time.Duration        `json:"-"`
+	StartupMs         []int64                `json:"startupMs"`
+	Tools             int                    `json:"tools"`
+	ToolTokens        int                    `json:"toolTokens"`
+	InstructionTokens int                    `json:"instructionTokens"`
+	Error             string                 `json:"error,omitempty"`
+}
+
+// Median is the median time to a finished initialize handshake
+func (r BenchResult) Median() time.Duration {
+	if len(r.Startup) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration{}, r.Startup...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[len(sorted)/2]
+}
+
+// Cold is the first start, which includes launcher downloads and caches
+func (r BenchResult) Cold() time.Duration {
+	if len(r.Startup) == 0 {
+		return 0
+	}
+	return r.Startup[0]
+}
+
+// Bench starts server runs times, timing each start until initialize has
+// completed, and lists its tools once to estimate their context cost.
+// Every run gets its own timeout.
+func Bench(ctx context.Context, server domain.MCPServer, runs int, timeout time.Duration, trace *Trace) BenchResult {
+	result := BenchResult{Server: server.Name, Scope: server.Scope, Transport: server.Transport()}
+
+	for i := 0; i < runs; i++ {
+		if err := benchRun(ctx, server, timeout, trace, &result, i == 0); err != nil {
+			result.Error = err.Error()
+			break
+		}
+		result.Runs++
+	}
+
+	for _, d := range result.Startup {
+		result.StartupMs = append(result.StartupMs, d.Milliseconds())
+	}
+	return result
+}
+
+func benchRun(ctx context.Context, server domain.MCPServer, timeout time.Duration, trace *Trace, result *BenchResult, listTools bool) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	client, err := Connect(ctx, server, trace)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	info, err := client.Initialize(ctx)
+	if err != nil {
+		return err
+	}
+	result.Startup = append(result.Startup, time.Since(start))
+	if !listTools {
+		return nil
+	}
+
+	result.ServerInfo = &info.ServerInfo
+	result.InstructionTokens = tokens.Estimate(info.Instructions)
+	if _, ok := info.Capabilities["tools"]; !ok {
+		return nil
+	}
+	tools, err := client.ListTools(ctx)
+	if err != nil {
+		return err
+	}
+	result.Tools = len(tools)
+	result.ToolTokens = ToolTokens(server.Name, tools)
+	return nil
+}
+
+// ToolTokens estimates the context cost of tool definitions as Claude Code
+// presents them to the model: prefixed name, description and input schema
+func ToolTokens(serverName string, tools []Tool) int {
+	total := 0
+	for _, tool := range tools {
+		total += tokens.EstimateJSON(map[string]any{
+			"name":         "mcp__" + serverName + "__" + tool.Name,
+			"description":  tool.Description,
+			"input_schema": tool.InputSchema,
+		})
+	}
+	return total
+}
@@ -0,0 +1,229 @@
+// Package mcpclient is a minimal MCP client used to start servers, run the
+// initialize handshake and list their tools.
+package mcpclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"claudectl/internal/domain"
+)
+
+var ErrUnsupportedTransport = errors.New("unsupported transport")
+
+// transport moves raw JSON-RPC messages to and from a server
+type transport interface {
+	send(ctx context.Context, data []byte) error
+	receive(ctx context.Context) ([]byte, error)
+	close() error
+}
+
+type Client struct {
+	name      string
+	transport transport
+	trace     *Trace
+	nextID    int
+}
+
+// Connect starts or opens a connection to server. Variables in the config
+// are expanded from the environment first, as Claude Code does. Traffic,
+// stderr and lifecycle events are recorded in trace, which may be nil.
+func Connect(ctx context.Context, server domain.MCPServer, trace *Trace) (*Client, error) {
+	client, err := connect(ctx, server, trace)
+	if err != nil {
+		trace.add(EventError, err.Error())
+		return nil, err
+	}
+	return client, nil
+}
+
+func connect(ctx context.Context, server domain.MCPServer, trace *Trace) (*Client, error) {
+	expanded, missing := server.Expanded()
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("environment variables not set: %s", strings.Join(missing, ", "))
+	}
+
+	var t transport
+	var err error
+	switch expanded.Transport() {
+	case domain.TransportStdio:
+		t, err = startStdio(expanded, trace)
+	case domain.TransportHTTP:
+		trace.add(EventInfo, "connecting to "+expanded.Url)
+		t, err = newHTTPTransport(expanded)
+	case domain.TransportSSE:
+		trace.add(EventInfo, "opening event stream "+expanded.Url)
+		t, err = openSSE(ctx, expanded, trace)
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedTransport, expanded.MCPType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Client{name: server.Name, transport: t, trace: trace}, nil
+}
+
+// Initialize runs the initialize handshake
+func (c *Client) Initialize(ctx context.Context) (*InitializeResult, error) {
+	params := map[string]any{
+		"protocolVersion": ProtocolVersion,
+		"capabilities":    map[string]any{},
+		"clientInfo":      Implementation{Name: "claudectl", Version: "0"},
+	}
+	var result InitializeResult
+	if err := c.call(ctx, "initialize", params, &result); err != nil {
+		return nil, fmt.Errorf("initialize: %w", err)
+	}
+	if err := c.notify(ctx, "notifications/initialized"); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListTools returns every tool, following pagination
+func (c *Client) ListTools(ctx context.Context) ([]Tool, error) {
+	var tools []Tool
+	cursor := ""
+	for {
+		params := map[string]any{}
+		if cursor != "" {
+			params["cursor"] = cursor
+		}
+		var page listToolsResult
+		if err := c.call(ctx, "tools/list", params, &page); err != nil {
+			return nil, fmt.Errorf("tools/list: %w", err)
+		}
+		tools = append(tools, page.Tools...)
+		if page.NextCursor == "" || page.NextCursor == cursor {
+			return tools, nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+func (c *Client) Close() error {
+	err := c.transport.close()
+	c.trace.add(EventInfo, "connection closed")
+	return err
+}
+
+func (c *Client) send(ctx context.Context, data []byte) error {
+	c.trace.add(EventSent, string(data))
+	if err := c.transport.send(ctx, data); err != nil {
+		c.trace.add(EventError, err.Error())
+		return err
+	}
+	return nil
+}
+
+func (c *Client) receive(ctx context.Context) ([]byte, error) {
+	data, err := c.transport.receive(ctx)
+	if err != nil {
+		c.trace.add(EventError, err.Error())
+		return nil, err
+	}
+	c.trace.add(EventRecv, string(data))
+	return data, nil
+}
+
+func (c *Client) notify(ctx context.Context, method string) error {
+	data, err := json.Marshal(message{JSONRPC: "2.0", Method: method})
+	if err != nil {
+		return err
+	}
+	return c.send(ctx, data)
+}
+
+// call sends a request and waits for its response, answering pings and
+// skipping notifications in the meantime
+func (c *Client) call(ctx context.Context, method string, params any, result any) error {
+	c.nextID++
+	id := json.RawMessage(fmt.Sprintf("%d", c.nextID))
+	data, err := json.Marshal(message{JSONRPC: "2.0", ID: &id, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	if err := c.send(ctx, data); err != nil {
+		return err
+	}
+
+	for {
+		raw, err := c.receive(ctx)
+		if err != nil {
+			return err
+		}
+		var msg message
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			return fmt.Errorf("invalid message from server: %w", err)
+		}
+
+		switch {
+		case msg.ID == nil:
+			continue
+		case msg.Method != "":
+			c.answer(ctx, msg)
+		case string(*msg.ID) == string(id):
+			if msg.Error != nil {
+				return msg.Error
+			}
+			return json.Unmarshal(msg.Result, result)
+		}
+	}
+}
+
+// answer replies to requests the server sends while we wait. Only ping is
+// supported; everything else gets "method not found".
+func (c *Client) answer(ctx context.Context, req message) {
+	reply := message{JSONRPC: "2.0", ID: req.ID}
+	if req.Method == "ping" {
+		reply.Result = json.RawMessage("{}")
+	} else {
+		reply.Error = &RPCError{Code: -32601, Message: "method not found"}
+	}
+	if data, err := json.Marshal(reply); err == nil {
+		_ = c.send(ctx, data)
+	}
+}
+
+// environ is the process environment with the server's env on top
+func environ(env map[string]string) []string {
+	result := os.Environ()
+	for k, v := range env {
+		result = append(result, k+"="+v)
+	}
+	return result
+}
+
+// Inspection is what a server reports about itself after the handshake
+type Inspection struct {
+	Info  *InitializeResult
+	Tools []Tool
+}
+
+// Inspect connects to server, runs the handshake and lists its tools. On
+// success the connection is left open for the caller to close.
+func Inspect(ctx context.Context, server domain.MCPServer, trace *Trace) (*Client, *Inspection, error) {
+	client, err := Connect(ctx, server, trace)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := client.Initialize(ctx)
+	if err != nil {
+		client.Close()
+		return nil, nil, err
+	}
+	inspection := &Inspection{Info: info}
+	if _, ok := info.Capabilities["tools"]; ok {
+		if inspection.Tools, err = client.ListTools(ctx); err != nil {
+			client.Close()
+			return nil, nil, err
+		}
+	}
+	trace.add(EventInfo, fmt.Sprintf("handshake complete: %s %s, %d tools",
+		info.ServerInfo.Name, info.ServerInfo.Version, len(inspection.Tools)))
+	return client, inspection, nil
+}
@@ -0,0 +1,303 @@
+package mcpclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"claudectl/internal/domain"
+)
+
+// httpTransport implements both remote transports. Streamable HTTP posts
+// every message to the server URL and reads responses from the reply; the
+// older SSE transport keeps a GET stream open and posts to the endpoint the
+// server announces on it.
+type httpTransport struct {
+	client   *http.Client
+	endpoint string
+	headers  map[string]string
+	inbox    chan []byte
+	errs     chan error
+	closing  chan struct{}
+	cancel   context.CancelFunc
+
+	mu        sync.Mutex
+	sessionID string
+	closed    bool
+}
+
+func newHTTPTransport(server *domain.MCPServer) (*httpTransport, error) {
+	headers, err := resolveHeaders(server)
+	if err != nil {
+		return nil, err
+	}
+	return &httpTransport{
+		client:   &http.Client{},
+		endpoint: server.Url,
+		headers:  headers,
+		inbox:    make(chan []byte, 16),
+		errs:     make(chan error, 1),
+		closing:  make(chan struct{}),
+	}, nil
+}
+
+// openSSE connects the event stream and waits for the endpoint event
+func openSSE(ctx context.Context, server *domain.MCPServer, trace *Trace) (*httpTransport, error) {
+	t, err := newHTTPTransport(server)
+	if err != nil {
+		return nil, err
+	}
+
+	streamCtx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+	req, err := http.NewRequestWithContext(streamCtx, http.MethodGet, server.Url, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	t.setHeaders(req)
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if err := statusError(resp); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	endpoint := make(chan string, 1)
+	go func() {
+		defer resp.Body.Close()
+		readSSE(resp.Body, func(event, data string) {
+			switch event {
+			case "endpoint":
+				if ref, err := url.Parse(strings.TrimSpace(data)); err == nil {
+					resolved := resp.Request.URL.ResolveReference(ref).String()
+					trace.add(EventInfo, "message endpoint "+resolved)
+					endpoint <- resolved
+				}
+			case "", "message":
+				t.push([]byte(data))
+			}
+		})
+		t.fail(fmt.Errorf("event stream closed"))
+	}()
+
+	select {
+	case t.endpoint = <-endpoint:
+		return t, nil
+	case err := <-t.errs:
+		cancel()
+		return nil, err
+	case <-ctx.Done():
+		cancel()
+		return nil, fmt.Errorf("waiting for endpoint event: %w", ctx.Err())
+	}
+}
+
+func (t *httpTransport) setHeaders(req *http.Request) {
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+	t.mu.Lock()
+	if t.sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", t.sessionID)
+	}
+	t.mu.Unlock()
+}
+
+func (t *httpTransport) send(ctx context.Context, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	t.setHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	if err := statusError(resp); err != nil {
+		return err
+	}
+	if id := resp.Header.Get("Mcp-Session-Id"); id != "" {
+		t.mu.Lock()
+		t.sessionID = id
+		t.mu.Unlock()
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/event-stream":
+		go func() {
+			defer resp.Body.Close()
+			readSSE(resp.Body, func(event, data string) {
+				if event == "" || event == "message" {
+					t.push([]byte(data))
+				}
+			})
+		}()
+	case "application/json":
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		var batch []json.RawMessage
+		if json.Unmarshal(body, &batch) == nil {
+			for _, msg := range batch {
+				t.push(msg)
+			}
+		} else if len(bytes.TrimSpace(body)) > 0 {
+			t.push(body)
+		}
+	default:
+		resp.Body.Close()
+	}
+	return nil
+}
+
+func (t *httpTransport) push(data []byte) {
+	select {
+	case t.inbox <- data:
+	case <-t.closing:
+	}
+}
+
+func (t *httpTransport) fail(err error) {
+	select {
+	case t.errs <- err:
+	default:
+	}
+}
+
+func (t *httpTransport) receive(ctx context.Context) ([]byte, error) {
+	select {
+	case data := <-t.inbox:
+		return data, nil
+	case err := <-t.errs:
+		return nil, err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// close ends the session; streamable HTTP servers are told with a DELETE
+func (t *httpTransport) close() error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil
+	}
+	t.closed = true
+	sessionID := t.sessionID
+	t.mu.Unlock()
+
+	close(t.closing)
+	if t.cancel != nil {
+		t.cancel()
+		return nil
+	}
+	if sessionID == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, t.endpoint, nil)
+	if err != nil {
+		return err
+	}
+	t.setHeaders(req)
+	if resp, err := t.client.Do(req); err == nil {
+		resp.Body.Close()
+	}
+	return nil
+}
+
+func statusError(resp *http.Response) error {
+	if resp.StatusCode < 400 {
+		return nil
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+	if text := strings.TrimSpace(string(body)); text != "" {
+		return fmt.Errorf("HTTP %s: %s", resp.Status, text)
+	}
+	return fmt.Errorf("HTTP %s", resp.Status)
+}
+
+// readSSE calls fn for every event in a server-sent events stream
+func readSSE(r io.Reader, fn func(event, data string)) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	var event string
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if len(data) > 0 {
+				fn(event, strings.Join(data, "\n"))
+			}
+			event, data = "", nil
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+	if len(data) > 0 {
+		fn(event, strings.Join(data, "\n"))
+	}
+}
+
+// resolveHeaders combines static headers with the output of headersHelper,
+// which prints a JSON object of headers
+func resolveHeaders(server *domain.MCPServer) (map[string]string, error) {
+	headers := map[string]string{}
+	for k, v := range server.Headers {
+		headers[k] = v
+	}
+	if server.HeadersHelper == "" {
+		return headers, nil
+	}
+
+	cmd := exec.Command("sh", "-c", server.HeadersHelper)
+	cmd.Env = append(os.Environ(),
+		"CLAUDE_CODE_MCP_SERVER_NAME="+server.Name,
+		"CLAUDE_CODE_MCP_SERVER_URL="+server.Url)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("headersHelper: %w", err)
+	}
+	var dynamic map[string]string
+	if err := json.Unmarshal(out, &dynamic); err != nil {
+		return nil, fmt.Errorf("headersHelper did not print a JSON object of headers: %w", err)
+	}
+	for k, v := range dynamic {
+		headers[k] = v
+	}
+	return headers, nil
+}
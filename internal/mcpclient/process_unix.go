@@ -0,0 +1,20 @@
+//go:build !windows
+
+package mcpclient
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup starts the server in its own process group so launchers
+// such as npx can be stopped together with the children they spawn
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+func killProcessGroup(cmd *exec.Cmd) {
+	if cmd.Process != nil {
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
@@ -0,0 +1,160 @@
+package mcpclient
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/utils"
+)
+
+// stderrTail is how many stderr lines are kept for error messages
+const stderrTail = 20
+
+// stdioTransport talks newline-delimited JSON-RPC to a child process
+type stdioTransport struct {
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	inbox   chan []byte
+	done    chan struct{}
+	closing chan struct{}
+
+	mu     sync.Mutex
+	stderr []string
+}
+
+func startStdio(server *domain.MCPServer, trace *Trace) (*stdioTransport, error) {
+	cmd := exec.Command(server.Command, server.Args...)
+	cmd.Env = environ(server.Env)
+	if root, err := utils.GetProjectRoot(); err == nil {
+		cmd.Dir = root
+	}
+	setProcessGroup(cmd)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+	trace.add(EventInfo, "starting "+strings.Join(cmd.Args, " "))
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	trace.add(EventInfo, fmt.Sprintf("started with pid %d", cmd.Process.Pid))
+
+	t := &stdioTransport{
+		cmd:     cmd,
+		stdin:   stdin,
+		inbox:   make(chan []byte, 16),
+		done:    make(chan struct{}),
+		closing: make(chan struct{}),
+	}
+
+	var readers sync.WaitGroup
+	readers.Add(2)
+	go func() {
+		defer readers.Done()
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			select {
+			case t.inbox <- []byte(line):
+			case <-t.closing:
+				return
+			}
+		}
+	}()
+	go func() {
+		defer readers.Done()
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			trace.add(EventStderr, scanner.Text())
+			t.mu.Lock()
+			t.stderr = append(t.stderr, scanner.Text())
+			if len(t.stderr) > stderrTail {
+				t.stderr = t.stderr[1:]
+			}
+			t.mu.Unlock()
+		}
+	}()
+	go func() {
+		readers.Wait()
+		_ = cmd.Wait()
+		trace.add(EventInfo, "process exited: "+cmd.ProcessState.String())
+		close(t.done)
+	}()
+
+	return t, nil
+}
+
+func (t *stdioTransport) send(ctx context.Context, data []byte) error {
+	_, err := t.stdin.Write(append(data, '\n'))
+	if err != nil {
+		return t.exitError()
+	}
+	return nil
+}
+
+func (t *stdioTransport) receive(ctx context.Context) ([]byte, error) {
+	select {
+	case data := <-t.inbox:
+		return data, nil
+	case <-t.done:
+		// Drain anything written just before exit
+		select {
+		case data := <-t.inbox:
+			return data, nil
+		default:
+		}
+		return nil, t.exitError()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (t *stdioTransport) exitError() error {
+	err := fmt.Errorf("server exited")
+	select {
+	case <-t.done:
+		err = fmt.Errorf("server exited with %s", t.cmd.ProcessState)
+	case <-time.After(time.Second):
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.stderr) > 0 {
+		return fmt.Errorf("%w: %s", err, t.stderr[len(t.stderr)-1])
+	}
+	return err
+}
+
+// close closes stdin, which well-behaved servers treat as shutdown, and
+// kills the process group if it is still running after a grace period
+func (t *stdioTransport) close() error {
+	close(t.closing)
+	t.stdin.Close()
+	select {
+	case <-t.done:
+	case <-time.After(2 * time.Second):
+		killProcessGroup(t.cmd)
+		<-t.done
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package mcpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventKind says where a trace event came from
+type EventKind string
+
+const (
+	EventSent   EventKind = "sent"
+	EventRecv   EventKind = "received"
+	EventStderr EventKind = "stderr"
+	EventInfo   EventKind = "info"
+	EventError  EventKind = "error"
+)
+
+// DefaultTraceSize is how many events a trace keeps
+const DefaultTraceSize = 1000
+
+type Event struct {
+	Time time.Time `json:"time"`
+	Kind EventKind `json:"kind"`
+	Data string    `json:"data"`
+}
+
+// Pretty returns the event data with JSON messages indented
+func (e Event) Pretty() string {
+	if e.Kind != EventSent && e.Kind != EventRecv {
+		return e.Data
+	}
+	var b bytes.Buffer
+	if err := json.Indent(&b, []byte(e.Data), "", "  "); err != nil {
+		return e.Data
+	}
+	return b.String()
+}
+
+// Format renders the event as a timestamped header followed by the
+// indented message
+func (e Event) Format() string {
+	header := fmt.Sprintf("%s %s %s", e.Time.Format("15:04:05.000"), e.Symbol(), e.Kind)
+	body := e.Pretty()
+	if !strings.Contains(body, "\n") && e.Kind != EventSent && e.Kind != EventRecv {
+		return header + "  " + body
+	}
+	return header + "\n  " + strings.ReplaceAll(body, "\n", "\n  ")
+}
+
+// Symbol is a one-character marker for the event direction
+func (e Event) Symbol() string {
+	switch e.Kind {
+	case EventSent:
+		return "→"
+	case EventRecv:
+		return "←"
+	case EventStderr:
+		return "!"
+	case EventError:
+		return "✗"
+	default:
+		return "•"
+	}
+}
+
+// Trace is a ring buffer of a server's JSON-RPC traffic, stderr output and
+// lifecycle events. A nil Trace discards everything.
+type Trace struct {
+	mu        sync.Mutex
+	events    []Event
+	next      int
+	full      bool
+	listeners map[int]func(Event)
+	nextID    int
+}
+
+func NewTrace(size int) *Trace {
+	return &Trace{events: make([]Event, size), listeners: map[int]func(Event){}}
+}
+
+func (t *Trace) add(kind EventKind, data string) {
+	if t == nil {
+		return
+	}
+	event := Event{Time: time.Now(), Kind: kind, Data: strings.TrimRight(data, "\r\n")}
+
+	t.mu.Lock()
+	t.events[t.next] = event
+	t.next = (t.next + 1) % len(t.events)
+	if t.next == 0 {
+		t.full = true
+	}
+	listeners := make([]func(Event), 0, len(t.listeners))
+	for _, fn := range t.listeners {
+		listeners = append(listeners, fn)
+	}
+	t.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn(event)
+	}
+}
+
+// Events returns the buffered events, oldest first
+func (t *Trace) Events() []Event {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.full {
+		return append([]Event{}, t.events[:t.next]...)
+	}
+	return append(append([]Event{}, t.events[t.next:]...), t.events[:t.next]...)
+}
+
+// Subscribe calls fn for every new event until the returned function is
+// called. fn runs on the goroutine that recorded the event.
+func (t *Trace) Subscribe(fn func(Event)) func() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	id := t.nextID
+	t.nextID++
+	t.listeners[id] = fn
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		delete(t.listeners, id)
+	}
+}
+
+// TraceLog keeps one trace per server
+type TraceLog struct {
+	mu     sync.Mutex
+	traces map[string]*Trace
+}
+
+func NewTraceLog() *TraceLog {
+	return &TraceLog{traces: map[string]*Trace{}}
+}
+
+// For returns the trace for a server, creating it on first use
+func (l *TraceLog) For(key string) *Trace {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	trace, ok := l.traces[key]
+	if !ok {
+		trace = NewTrace(DefaultTraceSize)
+		l.traces[key] = trace
+	}
+	return trace
+}
@@ -0,0 +1,386 @@
+package mcpformats
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"claudectl/internal/domain"
+)
+
+// Exported is a rendered config file for another client
+type Exported struct {
+	Format Format
+	Data   []byte
+	// Servers counts the servers written, which leaves out the skipped ones
+	Servers  int
+	Warnings []string
+}
+
+// envReferencePattern matches Claude Code's ${VAR} and ${VAR:-default}
+var envReferencePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-[^}]*)?\}`)
+
+// scopePrecedence mirrors Claude Code: local beats project beats user
+var scopePrecedence = map[domain.CapabilityScope]int{
+	domain.ScopeUser:    1,
+	domain.ScopeProject: 2,
+	domain.ScopeLocal:   3,
+}
+
+type exportWarner struct {
+	warnings []string
+}
+
+func (w *exportWarner) add(name, format string, args ...any) {
+	w.warnings = append(w.warnings, name+": "+fmt.Sprintf(format, args...))
+}
+
+// Export renders servers in the given format. When the same name exists in
+// several scopes only the one Claude Code would use is exported.
+func Export(format Format, servers []domain.MCPServer) (*Exported, error) {
+	w := &exportWarner{}
+	servers = effectiveServers(servers, w)
+
+	var data []byte
+	var written int
+	var err error
+	switch format {
+	case FormatClaudeDesktop:
+		data, written, err = exportJSON("mcpServers", servers, w, desktopEntry)
+	case FormatCursor:
+		data, written, err = exportJSON("mcpServers", servers, w, cursorEntry)
+	case FormatVSCode:
+		data, written, err = exportJSON("servers", servers, w, vscodeEntry)
+	case FormatCodexTOML:
+		data, written = exportCodex(servers, w), len(servers)
+	default:
+		err = fmt.Errorf("unknown format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Exported{Format: format, Data: data, Servers: written, Warnings: w.warnings}, nil
+}
+
+func effectiveServers(servers []domain.MCPServer, w *exportWarner) []domain.MCPServer {
+	index := map[string]int{}
+	var result []domain.MCPServer
+	for _, server := range servers {
+		i, seen := index[server.Name]
+		if !seen {
+			index[server.Name] = len(result)
+			result = append(result, server)
+			continue
+		}
+		if scopePrecedence[server.Scope] > scopePrecedence[result[i].Scope] {
+			w.add(server.Name, "defined in %s and %s scope; exported the %s entry", result[i].Scope, server.Scope, server.Scope)
+			result[i] = server
+		} else {
+			w.add(server.Name, "defined in %s and %s scope; exported the %s entry", result[i].Scope, server.Scope, result[i].Scope)
+		}
+	}
+	return result
+}
+
+// jsonEntry is the union of the fields the JSON based clients understand
+type jsonEntry struct {
+	Type    string            `json:"type,omitempty"`
+	Command string            `json:"command,omitempty"`
+	Args    []string          `json:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+	Url     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// exportJSON renders the entries under key and returns how many it wrote;
+// entry returns nil for servers the client cannot use
+func exportJSON(key string, servers []domain.MCPServer, w *exportWarner, entry func(domain.MCPServer, *exportWarner) *jsonEntry) ([]byte, int, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "{\n  %q: {", key)
+	written := 0
+	for _, server := range servers {
+		warnUnsupported(server, w)
+		e := entry(server, w)
+		if e == nil {
+			continue
+		}
+		value, err := json.MarshalIndent(e, "    ", "  ")
+		if err != nil {
+			return nil, 0, err
+		}
+		name, _ := json.Marshal(server.Name)
+		if written > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, "\n    %s: %s", name, value)
+		written++
+	}
+	if written > 0 {
+		b.WriteString("\n  ")
+	}
+	b.WriteString("}\n}\n")
+	return b.Bytes(), written, nil
+}
+
+func warnUnsupported(server domain.MCPServer, w *exportWarner) {
+	if server.HeadersHelper != "" {
+		w.add(server.Name, "headersHelper has no equivalent and was dropped")
+	}
+	if server.Timeout != 0 {
+		w.add(server.Name, "timeout has no equivalent and was dropped")
+	}
+	for _, key := range sortedExtraKeys(server.Extra) {
+		w.add(server.Name, "dropped field %q", key)
+	}
+}
+
+func desktopEntry(server domain.MCPServer, w *exportWarner) *jsonEntry {
+	if server.Transport() != domain.TransportStdio {
+		w.add(server.Name, "Claude Desktop only reads stdio servers from its config; skipped")
+		return nil
+	}
+	e := &jsonEntry{Command: server.Command, Args: server.Args, Env: server.Env}
+	if hasEnvReference(server) {
+		w.add(server.Name, "Claude Desktop does not expand ${VAR} references; set the real values")
+	}
+	return e
+}
+
+func cursorEntry(server domain.MCPServer, w *exportWarner) *jsonEntry {
+	e := editorEntry(server, w)
+	// Cursor tells transports apart by the presence of url
+	e.Type = ""
+	return e
+}
+
+func vscodeEntry(server domain.MCPServer, w *exportWarner) *jsonEntry {
+	return editorEntry(server, w)
+}
+
+// editorEntry builds an entry for VS Code style clients, which spell
+// environment references as ${env:VAR}
+func editorEntry(server domain.MCPServer, w *exportWarner) *jsonEntry {
+	convert := func(s string) string {
+		return envReferencePattern.ReplaceAllStringFunc(s, func(match string) string {
+			parts := envReferencePattern.FindStringSubmatch(match)
+			if parts[2] != "" {
+				w.add(server.Name, "default in %s is not supported and was dropped", match)
+			}
+			return "${env:" + parts[1] + "}"
+		})
+	}
+
+	e := &jsonEntry{Type: string(server.Transport())}
+	if server.Transport() == domain.TransportStdio {
+		e.Command = convert(server.Command)
+		for _, arg := range server.Args {
+			e.Args = append(e.Args, convert(arg))
+		}
+		e.Env = convertMap(server.Env, convert)
+	} else {
+		e.Url = convert(server.Url)
+		e.Headers = convertMap(server.Headers, convert)
+	}
+	return e
+}
+
+func convertMap(m map[string]string, convert func(string) string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = convert(v)
+	}
+	return result
+}
+
+func hasEnvReference(server domain.MCPServer) bool {
+	values := append([]string{server.Command, server.Url}, server.Args...)
+	for _, v := range server.Env {
+		values = append(values, v)
+	}
+	for _, v := range server.Headers {
+		values = append(values, v)
+	}
+	for _, v := range values {
+		if envReferencePattern.MatchString(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// exportCodex renders the [mcp_servers] tables of Codex's config.toml.
+// Codex does not expand variables inside values, so references are turned
+// into its env_vars, bearer_token_env_var and env_http_headers settings
+// where the shape allows it.
+func exportCodex(servers []domain.MCPServer, w *exportWarner) []byte {
+	var b bytes.Buffer
+	for i, server := range servers {
+		warnUnsupported(server, w)
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "[mcp_servers.%s]\n", tomlKey(server.Name))
+
+		literal := func(field, value string) string {
+			if envReferencePattern.MatchString(value) {
+				w.add(server.Name, "Codex does not expand %s in %s; written literally", envReferencePattern.FindString(value), field)
+			}
+			return value
+		}
+
+		if server.Transport() == domain.TransportStdio {
+			fmt.Fprintf(&b, "command = %s\n", tomlString(literal("command", server.Command)))
+			if len(server.Args) > 0 {
+				args := make([]string, len(server.Args))
+				for i, arg := range server.Args {
+					args[i] = tomlString(literal("args", arg))
+				}
+				fmt.Fprintf(&b, "args = [%s]\n", strings.Join(args, ", "))
+			}
+
+			env := map[string]string{}
+			var passThrough []string
+			for _, key := range sortedKeys(server.Env) {
+				value := server.Env[key]
+				if variable, ok := wholeReference(value); ok && variable == key {
+					passThrough = append(passThrough, tomlString(key))
+					continue
+				}
+				env[key] = literal("env."+key, value)
+			}
+			if len(env) > 0 {
+				fmt.Fprintf(&b, "env = %s\n", tomlInlineTable(env))
+			}
+			if len(passThrough) > 0 {
+				fmt.Fprintf(&b, "env_vars = [%s]\n", strings.Join(passThrough, ", "))
+			}
+			continue
+		}
+
+		if server.Transport() == domain.TransportSSE {
+			w.add(server.Name, "Codex only supports streamable HTTP; check the server offers it at the same URL")
+		}
+		fmt.Fprintf(&b, "url = %s\n", tomlString(literal("url", server.Url)))
+
+		headers := map[string]string{}
+		envHeaders := map[string]string{}
+		for _, name := range sortedKeys(server.Headers) {
+			value := server.Headers[name]
+			if token, ok := strings.CutPrefix(value, "Bearer "); ok && strings.EqualFold(name, "Authorization") {
+				if variable, ok := wholeReference(token); ok {
+					fmt.Fprintf(&b, "bearer_token_env_var = %s\n", tomlString(variable))
+					continue
+				}
+			}
+			if variable, ok := wholeReference(value); ok {
+				envHeaders[name] = variable
+				continue
+			}
+			headers[name] = literal("headers."+name, value)
+		}
+		if len(headers) > 0 {
+			fmt.Fprintf(&b, "http_headers = %s\n", tomlInlineTable(headers))
+		}
+		if len(envHeaders) > 0 {
+			fmt.Fprintf(&b, "env_http_headers = %s\n", tomlInlineTable(envHeaders))
+		}
+	}
+	return b.Bytes()
+}
+
+// wholeReference reports whether s is exactly one ${VAR} reference
+func wholeReference(s string) (string, bool) {
+	parts := envReferencePattern.FindStringSubmatch(s)
+	if parts == nil || parts[0] != s || parts[2] != "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+var bareTOMLKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func tomlKey(key string) string {
+	if bareTOMLKey.MatchString(key) {
+		return key
+	}
+	return tomlString(key)
+}
+
+// tomlString quotes s as a TOML basic string
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func tomlInlineTable(m map[string]string) string {
+	pairs := make([]string, 0, len(m))
+	for _, k := range sortedKeys(m) {
+		pairs = append(pairs, tomlKey(k)+" = "+tomlString(m[k]))
+	}
+	return "{ " + strings.Join(pairs, ", ") + " }"
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// WriteFile saves the rendered config to path, creating parent directories.
+// An existing file is only replaced when overwrite is set.
+func (e *Exported) WriteFile(path string, overwrite bool) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !overwrite {
+		flags |= os.O_EXCL
+	}
+	f, err := os.OpenFile(path, flags, 0644)
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("%s already exists", path)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(e.Data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,75 @@
+// Package mcpformats converts MCP server definitions between Claude Code
+// and the config formats used by other MCP clients.
+package mcpformats
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Format identifies a client's MCP config file format
+type Format string
+
+const (
+	FormatClaudeDesktop Format = "claude-desktop"
+	FormatVSCode        Format = "vscode"
+	FormatCursor        Format = "cursor"
+	FormatCodexTOML     Format = "codex-toml"
+)
+
+// ImportFormats lists the formats Import understands
+var ImportFormats = []Format{FormatClaudeDesktop, FormatVSCode, FormatCursor}
+
+// ExportFormats lists the formats Export can render
+var ExportFormats = []Format{FormatClaudeDesktop, FormatVSCode, FormatCursor, FormatCodexTOML}
+
+// DefaultFileName is the file name each client reads its MCP config from
+func DefaultFileName(format Format) string {
+	switch format {
+	case FormatClaudeDesktop:
+		return "claude_desktop_config.json"
+	case FormatCodexTOML:
+		return "config.toml"
+	default:
+		return "mcp.json"
+	}
+}
+
+// ParseFormat validates a format name given by the user
+func ParseFormat(s string, allowed []Format) (Format, error) {
+	names := make([]string, len(allowed))
+	for i, format := range allowed {
+		if string(format) == s {
+			return format, nil
+		}
+		names[i] = string(format)
+	}
+	return "", fmt.Errorf("unknown format %q (expected %s)", s, strings.Join(names, ", "))
+}
+
+// DetectFormat guesses the format of a config file from its name and shape
+func DetectFormat(path string, data []byte) (Format, error) {
+	doc, err := decodeJSONC(data)
+	if err != nil {
+		return "", err
+	}
+
+	if _, ok := doc["servers"]; ok {
+		return FormatVSCode, nil
+	}
+	if _, ok := doc["mcpServers"]; !ok {
+		return "", fmt.Errorf("%s has neither a \"servers\" nor an \"mcpServers\" key", path)
+	}
+
+	switch {
+	case filepath.Base(path) == "claude_desktop_config.json":
+		return FormatClaudeDesktop, nil
+	case strings.Contains(filepath.ToSlash(path), ".cursor/"):
+		return FormatCursor, nil
+	case strings.Contains(string(data), "${env:"):
+		return FormatCursor, nil
+	default:
+		return FormatClaudeDesktop, nil
+	}
+}
@@ -0,0 +1,160 @@
+package mcpformats
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/jsonedit"
+	"claudectl/internal/loaders"
+)
+
+// Imported is the result of converting another client's config
+type Imported struct {
+	Format   Format
+	Servers  []domain.MCPServer
+	Warnings []string
+}
+
+// vscodeInput is an entry of the "inputs" array in VS Code's mcp.json
+type vscodeInput struct {
+	Type        string `json:"type"`
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Password    bool   `json:"password"`
+}
+
+var (
+	variablePattern    = regexp.MustCompile(`\$\{([A-Za-z]+)(?::([^}]*))?\}`)
+	nonIdentifierChars = regexp.MustCompile(`[^A-Za-z0-9]+`)
+)
+
+// Import converts a config file in the given format into domain servers.
+// The servers have no scope yet; the caller decides where they go.
+func Import(format Format, data []byte) (*Imported, error) {
+	clean := stripJSONC(data)
+	result := &Imported{Format: format}
+
+	serversKey := "mcpServers"
+	if format == FormatVSCode {
+		serversKey = "servers"
+	}
+
+	names, err := jsonedit.Keys(clean, serversKey)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %w", serversKey, err)
+	}
+	raw, err := jsonedit.Find(clean, serversKey)
+	if err != nil {
+		return nil, err
+	}
+	var configs map[string]loaders.MCPServerConfig
+	if err := json.Unmarshal(raw, &configs); err != nil {
+		return nil, err
+	}
+
+	inputs := map[string]vscodeInput{}
+	if format == FormatVSCode {
+		if rawInputs, err := jsonedit.Find(clean, "inputs"); err == nil {
+			var list []vscodeInput
+			if err := json.Unmarshal(rawInputs, &list); err != nil {
+				return nil, fmt.Errorf("reading \"inputs\": %w", err)
+			}
+			for _, input := range list {
+				inputs[input.ID] = input
+			}
+		}
+	}
+
+	for _, name := range names {
+		config := configs[name]
+		warn := func(format string, args ...any) {
+			result.Warnings = append(result.Warnings, name+": "+fmt.Sprintf(format, args...))
+		}
+
+		for _, key := range sortedExtraKeys(config.Extra) {
+			warn("dropped field %q, which Claude Code does not support", key)
+		}
+		config.Extra = nil
+
+		if config.MCPType == "" && config.Url != "" {
+			config.MCPType = string(domain.TransportHTTP)
+			if strings.HasSuffix(strings.TrimRight(config.Url, "/"), "/sse") {
+				config.MCPType = string(domain.TransportSSE)
+			}
+		}
+		if config.MCPType == "" && config.Command != "" {
+			config.MCPType = string(domain.TransportStdio)
+		}
+
+		convert := func(s string) string {
+			return convertVariables(s, inputs, warn)
+		}
+		config.Command = convert(config.Command)
+		config.Url = convert(config.Url)
+		for i, arg := range config.Args {
+			config.Args[i] = convert(arg)
+		}
+		for k, v := range config.Env {
+			config.Env[k] = convert(v)
+		}
+		for k, v := range config.Headers {
+			config.Headers[k] = convert(v)
+		}
+
+		server := config.ToDomain(name, "")
+		for _, problem := range server.ConfigProblems() {
+			warn("%s", problem)
+		}
+		result.Servers = append(result.Servers, *server)
+	}
+
+	return result, nil
+}
+
+// convertVariables rewrites VS Code and Cursor style ${...} variables into
+// the ${VAR} environment references Claude Code understands
+func convertVariables(s string, inputs map[string]vscodeInput, warn func(string, ...any)) string {
+	return variablePattern.ReplaceAllStringFunc(s, func(match string) string {
+		parts := variablePattern.FindStringSubmatch(match)
+		kind, arg := parts[1], parts[2]
+
+		switch {
+		case kind == "env" && arg != "":
+			return "${" + arg + "}"
+		case kind == "input" && arg != "":
+			variable := strings.ToUpper(nonIdentifierChars.ReplaceAllString(arg, "_"))
+			hint := ""
+			if input, ok := inputs[arg]; ok && input.Description != "" {
+				hint = " (" + input.Description + ")"
+			}
+			warn("input %q%s became ${%s}; set it in your environment", arg, hint, variable)
+			return "${" + variable + "}"
+		case kind == "userHome":
+			return "${HOME}"
+		case kind == "pathSeparator":
+			return "/"
+		case kind == "workspaceFolder":
+			warn("${workspaceFolder} was replaced with \".\"; Claude Code starts servers in the project directory")
+			return "."
+		case arg == "" && strings.ToUpper(kind) == kind:
+			// Already a plain ${VAR} reference
+			return match
+		default:
+			warn("variable %s has no Claude Code equivalent and was kept as is", match)
+			return match
+		}
+	})
+}
+
+func sortedExtraKeys(extra map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(extra))
+	for k := range extra {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,241 @@
+// Package output defines the schema claudectl uses when printing
+// capabilities for scripts
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/loaders"
+)
+
+// SchemaVersion is bumped whenever a field is renamed, removed or changes
+// meaning. Adding a field does not change the version.
+const SchemaVersion = 1
+
+// Record is one capability in the output schema. Empty fields are left
+// out. Writers only print Content when it is selected since it can be
+// large.
+type Record struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Kind          string `json:"kind"`
+	Name          string `json:"name"`
+	Namespace     string `json:"namespace,omitempty"`
+	Scope         string `json:"scope"`
+	Description   string `json:"description,omitempty"`
+	SourceFile    string `json:"sourceFile,omitempty"`
+	SourceLine    int    `json:"sourceLine,omitempty"`
+	// Plugin is the plugin that contributes the capability
+	Plugin string `json:"plugin,omitempty"`
+
+	// MCP servers. Only the names of environment variables and headers are
+	// included since their values often hold secrets.
+	Transport string   `json:"transport,omitempty"`
+	Command   string   `json:"command,omitempty"`
+	Args      []string `json:"args,omitempty"`
+	URL       string   `json:"url,omitempty"`
+	EnvVars   []string `json:"envVars,omitempty"`
+	Headers   []string `json:"headers,omitempty"`
+
+	// Commands and agents
+	ArgumentHint string   `json:"argumentHint,omitempty"`
+	AllowedTools []string `json:"allowedTools,omitempty"`
+	Tools        []string `json:"tools,omitempty"`
+
+	// Plugins
+	Version     string   `json:"version,omitempty"`
+	Author      string   `json:"author,omitempty"`
+	Homepage    string   `json:"homepage,omitempty"`
+	Repository  string   `json:"repository,omitempty"`
+	License     string   `json:"license,omitempty"`
+	Keywords    []string `json:"keywords,omitempty"`
+	InstallPath string   `json:"installPath,omitempty"`
+
+	Warnings []string `json:"warnings,omitempty"`
+	Content  string   `json:"content,omitempty"`
+}
+
+// NewRecord converts a domain capability to a record
+func NewRecord(capability any) (Record, error) {
+	r := Record{SchemaVersion: SchemaVersion}
+	switch v := capability.(type) {
+	case domain.MCPServer:
+		r.setCapability(v.Capability)
+		r.SourceFile, r.SourceLine = v.FilePath, v.Line
+		r.Transport = string(v.Transport())
+		r.Command, r.Args, r.URL = v.Command, v.Args, v.Url
+		r.EnvVars, r.Headers = sortedKeys(v.Env), sortedKeys(v.Headers)
+	case domain.Command:
+		r.setCapability(v.Capability)
+		r.Namespace, r.SourceFile = v.Namespace, v.FilePath
+		r.ArgumentHint, r.AllowedTools = v.ArgumentHint, v.AllowedTools
+		r.Content = v.Content
+	case domain.Skill:
+		r.setCapability(v.Capability)
+		r.SourceFile, r.Content = v.FilePath, v.Content
+	case domain.Agent:
+		r.setCapability(v.Capability)
+		r.SourceFile, r.Tools, r.Content = v.FilePath, v.Tools, v.Content
+	case domain.Plugin:
+		r.setCapability(v.Capability)
+		r.SourceFile = loaders.PluginManifestPath(v.Path)
+		r.Version, r.Author = v.Version, v.Author.Name
+		r.Homepage, r.Repository, r.License = v.Homepage, v.Repository, v.License
+		r.Keywords, r.InstallPath = v.Keywords, v.Path
+	default:
+		return r, fmt.Errorf("unsupported capability type: %T", capability)
+	}
+	return r, nil
+}
+
+func (r *Record) setCapability(c domain.Capability) {
+	r.Kind, r.Name, r.Scope, r.Description = string(c.Type), c.Name, string(c.Scope), c.Description
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Fields lists the JSON names of every record field in schema order
+func Fields() []string {
+	t := reflect.TypeFor[Record]()
+	fields := make([]string, t.NumField())
+	for i := range fields {
+		fields[i] = jsonName(t.Field(i))
+	}
+	return fields
+}
+
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	return name
+}
+
+// fieldAliases are other names accepted for fields
+var fieldAliases = map[string]string{"type": "kind"}
+
+// ParseFields validates a comma-separated list of field names
+func ParseFields(s string) ([]string, error) {
+	all := Fields()
+	var fields []string
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if alias, ok := fieldAliases[field]; ok {
+			field = alias
+		}
+		if !slices.Contains(all, field) {
+			return nil, fmt.Errorf("unknown field %q (expected one of %s)", field, strings.Join(all, ", "))
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields given")
+	}
+	return fields, nil
+}
+
+// Value returns the field with the given JSON name
+func (r Record) Value(field string) (any, bool) {
+	v := reflect.ValueOf(r)
+	for i := 0; i < v.NumField(); i++ {
+		if jsonName(v.Type().Field(i)) == field {
+			return v.Field(i).Interface(), true
+		}
+	}
+	return nil, false
+}
+
+// Select returns the given fields of r, in that order, as a value that
+// marshals to a JSON object. schemaVersion is always included.
+func (r Record) Select(fields []string) Selection {
+	selection := Selection{{"schemaVersion", r.SchemaVersion}}
+	for _, field := range fields {
+		if field == "schemaVersion" {
+			continue
+		}
+		if value, ok := r.Value(field); ok {
+			selection = append(selection, KeyValue{field, value})
+		}
+	}
+	return selection
+}
+
+// Compact returns the fields of r that are set, except content, in schema
+// order. It matches how Record marshals to JSON.
+func (r Record) Compact() Selection {
+	var selection Selection
+	v := reflect.ValueOf(r)
+	for i := 0; i < v.NumField(); i++ {
+		field := jsonName(v.Type().Field(i))
+		value := v.Field(i)
+		if field == "content" {
+			continue
+		}
+		omitEmpty := strings.HasSuffix(v.Type().Field(i).Tag.Get("json"), ",omitempty")
+		if omitEmpty && (value.IsZero() || (value.Kind() == reflect.Slice && value.Len() == 0)) {
+			continue
+		}
+		selection = append(selection, KeyValue{field, value.Interface()})
+	}
+	return selection
+}
+
+type KeyValue struct {
+	Key   string
+	Value any
+}
+
+// Selection is an ordered subset of a record's fields
+type Selection []KeyValue
+
+func (s Selection) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, kv := range s {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(kv.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// MarshalYAML keeps the field order, which a map would lose
+func (s Selection) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, kv := range s {
+		var value yaml.Node
+		if err := value.Encode(kv.Value); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: kv.Key}, &value)
+	}
+	return node, nil
+}
@@ -0,0 +1,8 @@
+package output
+
+import _ "embed"
+
+// Schema is the JSON Schema of Record, printed by "claudectl schema"
+//
+//go:embed capability.schema.json
+var Schema []byte
@@ -0,0 +1,292 @@
+package output
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formats written by Writer
+const (
+	FormatTable    = "table"
+	FormatJSON     = "json"
+	FormatNDJSON   = "ndjson"
+	FormatYAML     = "yaml"
+	FormatCSV      = "csv"
+	FormatMarkdown = "markdown"
+	FormatTemplate = "template"
+)
+
+var Formats = []string{FormatTable, FormatJSON, FormatNDJSON, FormatYAML, FormatCSV, FormatMarkdown, FormatTemplate}
+
+// defaultColumns are the columns of table, csv and markdown output when no
+// fields are selected
+var defaultColumns = []string{"name", "scope", "kind", "description"}
+
+type Options struct {
+	Format string
+	// Fields selects the fields printed, in order. Nil prints every field
+	// but content in JSON, NDJSON and YAML, and defaultColumns otherwise.
+	Fields []string
+	// Sort orders the records by these fields, in turn. Nil keeps the
+	// order they are written in.
+	Sort []string
+	// Template is a text/template executed for each record
+	Template string
+}
+
+// Writer prints records in one of the output formats. Records are written
+// on Close, except NDJSON and templates without sorting, which stream so
+// large inventories start printing at once. Text formats show commands
+// with their namespace, as they are typed in Claude Code.
+type Writer struct {
+	w        io.Writer
+	opts     Options
+	template *template.Template
+	records  []Record
+}
+
+func NewWriter(w io.Writer, opts Options) (*Writer, error) {
+	tmpl, err := opts.parse()
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{w: w, opts: opts, template: tmpl}, nil
+}
+
+// Validate checks the options without creating a writer
+func (o Options) Validate() error {
+	_, err := o.parse()
+	return err
+}
+
+func (o Options) parse() (*template.Template, error) {
+	if !slices.Contains(Formats, o.Format) {
+		return nil, fmt.Errorf("invalid output %q (expected %s)", o.Format, strings.Join(Formats, ", "))
+	}
+	for _, field := range o.Sort {
+		if _, ok := (Record{}).Value(field); !ok {
+			return nil, fmt.Errorf("cannot sort by unknown field %q", field)
+		}
+	}
+	if o.Format != FormatTemplate {
+		if o.Template != "" {
+			return nil, fmt.Errorf("a template needs --output template")
+		}
+		return nil, nil
+	}
+	if o.Template == "" {
+		return nil, fmt.Errorf("--output template needs --template")
+	}
+	tmpl, err := template.New("record").
+		Funcs(template.FuncMap{"join": strings.Join}).
+		Parse(unescape(o.Template))
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// unescape expands \t and \n, which shells pass through literally
+func unescape(s string) string {
+	return strings.NewReplacer(`\t`, "\t", `\n`, "\n", `\\`, `\`).Replace(s)
+}
+
+func (w *Writer) streams() bool {
+	return w.opts.Sort == nil && (w.opts.Format == FormatNDJSON || w.opts.Format == FormatTemplate)
+}
+
+func (w *Writer) Write(r Record) error {
+	if w.streams() {
+		return w.writeLine(r)
+	}
+	w.records = append(w.records, r)
+	return nil
+}
+
+// writeLine writes a record in a line-based format
+func (w *Writer) writeLine(r Record) error {
+	if w.opts.Format == FormatTemplate {
+		var b strings.Builder
+		if err := w.template.Execute(&b, r); err != nil {
+			return err
+		}
+		if !strings.HasSuffix(b.String(), "\n") {
+			b.WriteString("\n")
+		}
+		_, err := io.WriteString(w.w, b.String())
+		return err
+	}
+	data, err := json.Marshal(w.selection(r))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w.w, "%s\n", data)
+	return err
+}
+
+// Close sorts and writes the buffered records
+func (w *Writer) Close() error {
+	if w.streams() {
+		return nil
+	}
+	SortRecords(w.records, w.opts.Sort)
+
+	switch w.opts.Format {
+	case FormatJSON, FormatYAML:
+		selections := make([]Selection, len(w.records))
+		for i, r := range w.records {
+			selections[i] = w.selection(r)
+		}
+		var data []byte
+		var err error
+		if w.opts.Format == FormatJSON {
+			data, err = json.MarshalIndent(selections, "", "  ")
+			data = append(data, '\n')
+		} else {
+			data, err = yaml.Marshal(selections)
+		}
+		if err != nil {
+			return err
+		}
+		_, err = w.w.Write(data)
+		return err
+	case FormatTable:
+		return w.writeTable()
+	case FormatCSV:
+		return w.writeCSV()
+	case FormatMarkdown:
+		return w.writeMarkdown()
+	default:
+		for _, r := range w.records {
+			if err := w.writeLine(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func (w *Writer) selection(r Record) Selection {
+	if w.opts.Fields != nil {
+		return r.Select(w.opts.Fields)
+	}
+	return r.Compact()
+}
+
+func (w *Writer) columns() []string {
+	if w.opts.Fields != nil {
+		return w.opts.Fields
+	}
+	return defaultColumns
+}
+
+func (w *Writer) writeTable() error {
+	tw := tabwriter.NewWriter(w.w, 0, 0, 3, ' ', 0)
+	columns := w.columns()
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = strings.ToUpper(column)
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, r := range w.records {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cells[i] = strings.ReplaceAll(r.Text(column), "\n", " ")
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+func (w *Writer) writeCSV() error {
+	cw := csv.NewWriter(w.w)
+	columns := w.columns()
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	for _, r := range w.records {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = r.Text(column)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (w *Writer) writeMarkdown() error {
+	columns := w.columns()
+	var b strings.Builder
+	b.WriteString("| " + strings.Join(columns, " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(columns)) + "\n")
+	escape := strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+	for _, r := range w.records {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cells[i] = escape.Replace(r.Text(column))
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	_, err := io.WriteString(w.w, b.String())
+	return err
+}
+
+// SortRecords orders records by the given fields, in turn, keeping the
+// original order of records that compare equal
+func SortRecords(records []Record, fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+	slices.SortStableFunc(records, func(a, b Record) int {
+		for _, field := range fields {
+			if c := compareField(a, b, field); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+}
+
+func compareField(a, b Record, field string) int {
+	av, _ := a.Value(field)
+	bv, _ := b.Value(field)
+	if ai, ok := av.(int); ok {
+		return cmp.Compare(ai, bv.(int))
+	}
+	return cmp.Compare(strings.ToLower(a.Text(field)), strings.ToLower(b.Text(field)))
+}
+
+// Text formats a field as one cell of text. Lists are joined with commas,
+// and a command's name includes its namespace.
+func (r Record) Text(field string) string {
+	if field == "name" && r.Namespace != "" {
+		return r.Namespace + ":" + r.Name
+	}
+	value, _ := r.Value(field)
+	switch v := value.(type) {
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ", ")
+	case int:
+		if v == 0 {
+			return ""
+		}
+		return strconv.Itoa(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,119 @@
+// Package overview summarises a setup at a glance: how many capabilities
+// of each type every scope has, what they cost in context and what needs
+// attention.
+package overview
+
+import (
+	"claudectl/internal/budget"
+	"claudectl/internal/domain"
+	"claudectl/internal/settings"
+	"claudectl/internal/viewmodels"
+)
+
+// Kinds are the capability types summarised, in tab order
+var Kinds = []domain.CapabilityType{
+	domain.TypeMCP,
+	domain.TypeCommand,
+	domain.TypeSkill,
+	domain.TypePlugin,
+	domain.TypeAgent,
+}
+
+// Entry summarises the capabilities of one type in one scope
+type Entry struct {
+	Kind  domain.CapabilityType
+	Scope domain.CapabilityScope
+	Count int
+	// Bytes is the total size of their content
+	Bytes int
+	// Tokens is the estimated context cost, including what enabled plugins
+	// ship and not counting Unmeasured MCP servers
+	Tokens     int
+	Unmeasured int
+	// Enabled and Disabled count MCP servers and plugins, which settings
+	// can turn off
+	Enabled  int
+	Disabled int
+	// Updates are plugins whose marketplace offers another version
+	Updates []Update
+	// Warnings is the number of problems found with single capabilities
+	Warnings int
+}
+
+// Toggleable reports whether settings can turn the entry's capabilities
+// off
+func (e Entry) Toggleable() bool {
+	return e.Kind == domain.TypeMCP || e.Kind == domain.TypePlugin
+}
+
+// Update is a plugin with a newer version available
+type Update struct {
+	Name      string
+	Installed string
+	Latest    string
+}
+
+// Report is the overview of everything loaded
+type Report struct {
+	// Entries are ordered by kind, then user, project and local scope.
+	// Local entries are only present when not empty.
+	Entries []Entry
+	// Problems are files that could not be loaded
+	Problems []string
+}
+
+// New summarises capabilities. Settings decide which plugins and project
+// MCP servers are disabled; measurements give MCP servers their cost.
+func New(capabilities []viewmodels.CapabilityViewModel, s *settings.Settings, measurements budget.Measurements) *Report {
+	report := &Report{}
+	for _, kind := range Kinds {
+		for _, scope := range settings.Scopes {
+			entry := Entry{Kind: kind, Scope: scope}
+			for _, vm := range capabilities {
+				if vm.GetType() == kind && vm.GetScope() == scope {
+					entry.add(vm, s, measurements)
+				}
+			}
+			if scope == domain.ScopeLocal && entry.Count == 0 {
+				continue
+			}
+			report.Entries = append(report.Entries, entry)
+		}
+	}
+	return report
+}
+
+func (e *Entry) add(vm viewmodels.CapabilityViewModel, s *settings.Settings, measurements budget.Measurements) {
+	e.Count++
+	e.Bytes += len(vm.GetContent())
+	if wp, ok := vm.(viewmodels.WarningProvider); ok {
+		e.Warnings += len(wp.Warnings())
+	}
+
+	enabled := true
+	switch vm := vm.(type) {
+	case *viewmodels.MCPServerViewModel:
+		// Only servers from the project's .mcp.json need approval
+		enabled = vm.GetScope() != domain.ScopeProject || !s.MCPServerDisabled(vm.GetName())
+	case *viewmodels.PluginViewModel:
+		enabled = s.PluginEnabled(vm.Key())
+		if latest := vm.UpdateVersion(); latest != "" {
+			e.Updates = append(e.Updates, Update{Name: vm.GetName(), Installed: vm.Version(), Latest: latest})
+		}
+	}
+	if enabled {
+		e.Enabled++
+	} else {
+		e.Disabled++
+	}
+
+	// A disabled plugin ships nothing into the context, as in the budget
+	if !enabled && vm.GetType() == domain.TypePlugin {
+		return
+	}
+	tokens, measured := budget.CapabilityTokens(vm, measurements)
+	e.Tokens += tokens
+	if !measured {
+		e.Unmeasured++
+	}
+}
@@ -0,0 +1,174 @@
+// Package query filters capabilities by type, scope, name, namespace, text
+// and the plugin that contributes them. The list command builds queries
+// from its flags and the TUI from what is typed into its filter, so a
+// filter means the same thing in both.
+package query
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"claudectl/internal/domain"
+)
+
+// Item is what a query looks at. Capability view models implement it.
+type Item interface {
+	GetName() string
+	// FilterValue is the full name, e.g. a command's namespace:name
+	FilterValue() string
+	GetDescription() string
+	GetContent() string
+	GetType() domain.CapabilityType
+	GetScope() domain.CapabilityScope
+	GetFilePath() string
+}
+
+// Query matches items that satisfy every predicate that is set. The zero
+// Query matches everything.
+type Query struct {
+	Types []domain.CapabilityType
+	Scope domain.CapabilityScope
+	Name  *Pattern
+	// Namespace is the command namespace full names must be in. Nested
+	// namespaces are inside their parents.
+	Namespace string
+	// Grep is text that the description or content must contain, ignoring
+	// case
+	Grep string
+	// PluginDirs are the install directories of plugins whose capabilities
+	// match
+	PluginDirs []string
+}
+
+// Match reports whether item satisfies the query
+func (q Query) Match(item Item) bool {
+	if len(q.Types) > 0 && !slices.Contains(q.Types, item.GetType()) {
+		return false
+	}
+	if q.Scope != "" && item.GetScope() != q.Scope {
+		return false
+	}
+	if q.Name != nil && !q.Name.Match(item.FilterValue()) && !q.Name.Match(item.GetName()) {
+		return false
+	}
+	if q.Namespace != "" && !inNamespace(item.FilterValue(), q.Namespace) {
+		return false
+	}
+	if q.Grep != "" && !containsFold(item.GetDescription(), q.Grep) && !containsFold(item.GetContent(), q.Grep) {
+		return false
+	}
+	if len(q.PluginDirs) > 0 && !slices.ContainsFunc(q.PluginDirs, func(dir string) bool {
+		return within(dir, item.GetFilePath())
+	}) {
+		return false
+	}
+	return true
+}
+
+// Filter returns the items matching q, keeping their order
+func Filter[T Item](items []T, q Query) []T {
+	var matched []T
+	for _, item := range items {
+		if q.Match(item) {
+			matched = append(matched, item)
+		}
+	}
+	return matched
+}
+
+// ParseTerms splits filter text into the scope:, ns: and plugin: terms it
+// qualifies the query with and the words left over. Plugins are returned
+// by name for the caller to resolve into PluginDirs. Terms with an
+// unknown key or an invalid scope are left in the text.
+func ParseTerms(text string) (q Query, plugins []string, rest string) {
+	var words []string
+	for _, word := range strings.Fields(text) {
+		key, value, ok := strings.Cut(word, ":")
+		if !ok || value == "" {
+			words = append(words, word)
+			continue
+		}
+		switch key {
+		case "scope":
+			scope, err := domain.ParseScope(value)
+			if err != nil {
+				words = append(words, word)
+				continue
+			}
+			q.Scope = scope
+		case "ns":
+			q.Namespace = value
+		case "plugin":
+			plugins = append(plugins, value)
+		default:
+			words = append(words, word)
+		}
+	}
+	return q, plugins, strings.Join(words, " ")
+}
+
+// inNamespace reports whether a full name such as ns:sub:name is inside
+// namespace
+func inNamespace(fullName, namespace string) bool {
+	i := strings.LastIndex(fullName, ":")
+	if i < 0 {
+		return false
+	}
+	ns := fullName[:i]
+	return ns == namespace || strings.HasPrefix(ns, namespace+":")
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// within reports whether file is inside dir
+func within(dir, file string) bool {
+	if file == "" {
+		return false
+	}
+	rel, err := filepath.Rel(dir, file)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// Pattern matches names with a glob, or with a regular expression when
+// written between slashes, e.g. /^git-/. Globs match the whole name and
+// ignore case; regular expressions match anywhere.
+type Pattern struct {
+	glob string
+	re   *regexp.Regexp
+}
+
+func ParsePattern(s string) (*Pattern, error) {
+	if len(s) >= 2 && strings.HasPrefix(s, "/") && strings.HasSuffix(s, "/") {
+		re, err := regexp.Compile(s[1 : len(s)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %s: %w", s, err)
+		}
+		return &Pattern{re: re}, nil
+	}
+	glob := strings.ToLower(s)
+	if _, err := path.Match(glob, ""); err != nil {
+		return nil, fmt.Errorf("invalid glob %q: %w", s, err)
+	}
+	return &Pattern{glob: glob}, nil
+}
+
+func (p *Pattern) Match(name string) bool {
+	if p.re != nil {
+		return p.re.MatchString(name)
+	}
+	matched, _ := path.Match(p.glob, strings.ToLower(name))
+	return matched
+}
+
+func (p *Pattern) String() string {
+	if p.re != nil {
+		return "/" + p.re.String() + "/"
+	}
+	return p.glob
+}
@@ -0,0 +1,247 @@
+// Package scaffold creates new commands, skills and agents from templates.
+// Built-in templates can be overridden by placing command.md, skill.md or
+// agent.md in a team template directory.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/utils"
+)
+
+//go:embed templates/*.md
+var builtinTemplates embed.FS
+
+// TemplateDirEnv overrides where team templates are looked up
+const TemplateDirEnv = "CLAUDECTL_TEMPLATE_DIR"
+
+// Kinds lists the capability types that can be scaffolded
+var Kinds = []domain.CapabilityType{domain.TypeCommand, domain.TypeSkill, domain.TypeAgent}
+
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+type Params struct {
+	Kind        domain.CapabilityType
+	Name        string
+	Namespace   string
+	Scope       domain.CapabilityScope
+	Description string
+	// TemplateDir takes precedence over the default template lookup
+	TemplateDir string
+}
+
+// templateData is what templates can refer to
+type templateData struct {
+	Name        string
+	Namespace   string
+	Description string
+	Title       string
+	Scope       domain.CapabilityScope
+}
+
+// ParseKind validates a capability type given by the user
+func ParseKind(s string) (domain.CapabilityType, error) {
+	for _, kind := range Kinds {
+		if string(kind) == s {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("unknown type %q (expected command, skill or agent)", s)
+}
+
+// SplitName separates "ns:name" or "ns/name" into namespace and name
+func SplitName(s string) (namespace, name string) {
+	s = strings.ReplaceAll(s, "/", ":")
+	if i := strings.LastIndex(s, ":"); i >= 0 {
+		return s[:i], s[i+1:]
+	}
+	return "", s
+}
+
+// ValidateName checks a capability name and, for commands, its namespace
+func ValidateName(kind domain.CapabilityType, namespace, name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid name %q: use lowercase letters, digits and hyphens", name)
+	}
+	if namespace == "" {
+		return nil
+	}
+	if kind != domain.TypeCommand {
+		return fmt.Errorf("only commands have namespaces")
+	}
+	for _, part := range strings.Split(namespace, ":") {
+		if !namePattern.MatchString(part) {
+			return fmt.Errorf("invalid namespace %q: use lowercase letters, digits and hyphens", namespace)
+		}
+	}
+	return nil
+}
+
+// Path returns the file a new capability is written to
+func Path(kind domain.CapabilityType, scope domain.CapabilityScope, namespace, name string) (string, error) {
+	base, err := utils.GetScopeBaseDir(scope)
+	if err != nil {
+		return "", err
+	}
+	switch kind {
+	case domain.TypeCommand:
+		parts := append([]string{base, "commands"}, strings.Split(namespace, ":")...)
+		return filepath.Join(append(parts, name+".md")...), nil
+	case domain.TypeSkill:
+		return filepath.Join(base, "skills", name, "SKILL.md"), nil
+	case domain.TypeAgent:
+		return filepath.Join(base, "agents", name+".md"), nil
+	default:
+		return "", fmt.Errorf("cannot create a %s", kind)
+	}
+}
+
+// Create renders the template for p.Kind and writes it, refusing to replace
+// an existing file. It returns the path of the new file.
+func Create(p Params) (string, error) {
+	if p.Scope != domain.ScopeUser && p.Scope != domain.ScopeProject {
+		return "", fmt.Errorf("%ss can only be created in user or project scope", p.Kind)
+	}
+	if err := ValidateName(p.Kind, p.Namespace, p.Name); err != nil {
+		return "", err
+	}
+
+	path, err := Path(p.Kind, p.Scope, p.Namespace, p.Name)
+	if err != nil {
+		return "", err
+	}
+	content, err := Render(p)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if errors.Is(err, fs.ErrExist) {
+		return "", fmt.Errorf("%s already exists", path)
+	}
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return "", err
+	}
+	return path, f.Close()
+}
+
+// Render fills in the template for p.Kind
+func Render(p Params) ([]byte, error) {
+	source, origin, err := loadTemplate(p.Kind, p.TemplateDir)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(origin).Funcs(template.FuncMap{"yaml": yamlString}).Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", origin, err)
+	}
+
+	description := p.Description
+	if description == "" {
+		description = fmt.Sprintf("TODO: describe when to use the %s %s", p.Name, p.Kind)
+	}
+	data := templateData{
+		Name:        p.Name,
+		Namespace:   p.Namespace,
+		Description: description,
+		Title:       title(p.Name),
+		Scope:       p.Scope,
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return nil, fmt.Errorf("template %s: %w", origin, err)
+	}
+	if !hasFrontmatter(b.Bytes()) {
+		return nil, fmt.Errorf("template %s does not produce a --- frontmatter block", origin)
+	}
+	return b.Bytes(), nil
+}
+
+// TemplateDirs lists where team templates are looked up, in order
+func TemplateDirs(override string) []string {
+	if override != "" {
+		return []string{override}
+	}
+	if dir := os.Getenv(TemplateDirEnv); dir != "" {
+		return []string{dir}
+	}
+	var dirs []string
+	if dir, err := utils.GetProjectClaudeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(dir, "templates"))
+	}
+	if dir, err := utils.GetUserClaudeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(dir, "templates"))
+	}
+	return dirs
+}
+
+func loadTemplate(kind domain.CapabilityType, override string) (string, string, error) {
+	file := string(kind) + ".md"
+	for _, dir := range TemplateDirs(override) {
+		path := filepath.Join(dir, file)
+		data, err := os.ReadFile(path)
+		if err == nil {
+			return string(data), path, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", "", err
+		}
+	}
+	if override != "" {
+		return "", "", fmt.Errorf("no %s in template directory %s", file, override)
+	}
+
+	data, err := builtinTemplates.ReadFile("templates/" + file)
+	if err != nil {
+		return "", "", err
+	}
+	return string(data), "built-in " + file, nil
+}
+
+func hasFrontmatter(content []byte) bool {
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	if !bytes.HasPrefix(content, []byte("---\n")) {
+		return false
+	}
+	return bytes.Contains(content[4:], []byte("\n---\n")) || bytes.HasPrefix(content[4:], []byte("---\n"))
+}
+
+var plainYAML = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 _.,()/'-]*$`)
+
+// yamlString quotes s unless it is safe as a plain YAML scalar
+func yamlString(s string) string {
+	if plainYAML.MatchString(s) && !strings.HasSuffix(s, " ") {
+		return s
+	}
+	return strconv.Quote(s)
+}
+
+func title(name string) string {
+	words := strings.Split(name, "-")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
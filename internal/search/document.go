@@ -0,0 +1,203 @@
+// Package search ranks capabilities, memory files and plugin manifests
+// against free-text queries with BM25. The CLI and the TUI share it so a
+// search finds the same things in both.
+package search
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"claudectl/internal/budget"
+	"claudectl/internal/domain"
+	"claudectl/internal/loaders"
+	"claudectl/internal/viewmodels"
+)
+
+// KindMemory is the kind of CLAUDE.md memory file documents
+const KindMemory = "memory"
+
+// Document is one searchable item
+type Document struct {
+	// Kind is a capability type or KindMemory
+	Kind        string
+	Name        string
+	Scope       domain.CapabilityScope
+	Description string
+	Path        string
+	// Plugin is the plugin that contributes the item, if any
+	Plugin string
+	// Lines is the searched text with line numbers in Path
+	Lines []Line
+}
+
+// Line is one line of a document. Number is 0 when the line has no
+// position in a file.
+type Line struct {
+	Number int
+	Text   string
+}
+
+// Documents builds the documents for capabilities, everything plugins
+// contribute and memory files
+func Documents(capabilities []viewmodels.CapabilityViewModel, plugins []domain.Plugin, memory []budget.MemoryFile) []Document {
+	var docs []Document
+	for _, vm := range capabilities {
+		docs = append(docs, FromCapability(vm, ""))
+	}
+	for _, plugin := range plugins {
+		var contents []any
+		for _, server := range plugin.MCPServers {
+			contents = append(contents, server)
+		}
+		for _, command := range plugin.Commands {
+			contents = append(contents, command)
+		}
+		for _, skill := range plugin.Skills {
+			contents = append(contents, skill)
+		}
+		for _, agent := range plugin.Agents {
+			contents = append(contents, agent)
+		}
+		for _, value := range contents {
+			if vm, err := viewmodels.ToDomainViewModel(value); err == nil {
+				docs = append(docs, FromCapability(vm, plugin.Name))
+			}
+		}
+	}
+	for _, file := range memory {
+		docs = append(docs, FromMemory(file))
+	}
+	return docs
+}
+
+// FromCapability builds the document for a capability. Markdown files and
+// plugin manifests are read in full, frontmatter included; MCP entries are
+// described by their command, URL and the names of their variables, never
+// their values.
+func FromCapability(vm viewmodels.CapabilityViewModel, plugin string) Document {
+	doc := Document{
+		Kind:        string(vm.GetType()),
+		Name:        vm.FilterValue(),
+		Scope:       vm.GetScope(),
+		Description: vm.GetDescription(),
+		Path:        vm.GetFilePath(),
+		Plugin:      plugin,
+	}
+	switch vm := vm.(type) {
+	case *viewmodels.MCPServerViewModel:
+		doc.Lines = serverLines(vm.Server())
+	case *viewmodels.PluginViewModel:
+		manifest := loaders.PluginManifestPath(vm.GetFilePath())
+		if lines, err := readLines(manifest); err == nil {
+			doc.Path, doc.Lines = manifest, lines
+		}
+	default:
+		if lines, err := readLines(doc.Path); err == nil {
+			doc.Lines = lines
+		} else {
+			doc.Lines = splitLines(vm.GetContent(), 1)
+		}
+	}
+	return doc
+}
+
+// FromMemory builds the document for a memory file, read from disk so line
+// numbers match the file rather than its expanded imports
+func FromMemory(file budget.MemoryFile) Document {
+	doc := Document{
+		Kind:  KindMemory,
+		Name:  file.Name(),
+		Scope: file.Scope,
+		Path:  file.Path,
+	}
+	if lines, err := readLines(file.Path); err == nil {
+		doc.Lines = lines
+	} else {
+		doc.Lines = splitLines(file.Content, 1)
+	}
+	return doc
+}
+
+// Location is the path and line of a match, relative to the working
+// directory or ~ when possible
+func (d *Document) Location(line int) string {
+	if d.Path == "" {
+		return ""
+	}
+	path := displayPath(d.Path)
+	if line == 0 {
+		return path
+	}
+	return fmt.Sprintf("%s:%d", path, line)
+}
+
+// serverLines describes an MCP entry, all at the line where it starts
+func serverLines(server *domain.MCPServer) []Line {
+	texts := []string{server.Name}
+	if server.Command != "" {
+		texts = append(texts, strings.TrimSpace(server.Command+" "+strings.Join(server.Args, " ")))
+	}
+	if server.Url != "" {
+		texts = append(texts, server.Url)
+	}
+	if len(server.Env) > 0 {
+		texts = append(texts, "env: "+strings.Join(sortedKeys(server.Env), " "))
+	}
+	if len(server.Headers) > 0 {
+		texts = append(texts, "headers: "+strings.Join(sortedKeys(server.Headers), " "))
+	}
+	lines := make([]Line, len(texts))
+	for i, text := range texts {
+		lines[i] = Line{Number: server.Line, Text: text}
+	}
+	return lines
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func readLines(path string) ([]Line, error) {
+	if path == "" {
+		return nil, os.ErrNotExist
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return splitLines(string(data), 1), nil
+}
+
+func splitLines(text string, first int) []Line {
+	if text == "" {
+		return nil
+	}
+	texts := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	lines := make([]Line, len(texts))
+	for i, t := range texts {
+		lines[i] = Line{Number: first + i, Text: strings.TrimSuffix(t, "\r")}
+	}
+	return lines
+}
+
+func displayPath(path string) string {
+	if cwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(cwd, path); err == nil && filepath.IsLocal(rel) {
+			return rel
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if rel, err := filepath.Rel(home, path); err == nil && filepath.IsLocal(rel) {
+			return filepath.Join("~", rel)
+		}
+	}
+	return path
+}
@@ -0,0 +1,331 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BM25 parameters
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+// Term frequency weights of the fields a document is indexed by
+const (
+	nameWeight        = 3
+	descriptionWeight = 2
+	lineWeight        = 1
+)
+
+// prefixWeight scales matches where a query term is only the start of a
+// word, so "depl" finds "deploy" while exact words rank first
+const prefixWeight = 0.5
+
+// maxSnippet is the longest snippet, in runes, before it is cut around the
+// first match
+const maxSnippet = 120
+
+// Index ranks documents for queries
+type Index struct {
+	docs []Document
+	// freqs are the weighted term frequencies of each document
+	freqs     []map[string]float64
+	lengths   []float64
+	avgLength float64
+	// docFreq is how many documents contain each term
+	docFreq map[string]int
+	// vocabulary is every indexed term, sorted for prefix lookups
+	vocabulary []string
+}
+
+// Result is a document that matches a query
+type Result struct {
+	Document *Document
+	Score    float64
+	Snippet  Snippet
+}
+
+// Snippet is the line of a document that best matches a query
+type Snippet struct {
+	Line int
+	Text string
+	// Matches are the byte ranges of matched words in Text
+	Matches [][2]int
+}
+
+// Location is the path and line of the snippet
+func (r Result) Location() string {
+	return r.Document.Location(r.Snippet.Line)
+}
+
+// NewIndex indexes docs
+func NewIndex(docs []Document) *Index {
+	ix := &Index{
+		docs:    docs,
+		freqs:   make([]map[string]float64, len(docs)),
+		lengths: make([]float64, len(docs)),
+		docFreq: map[string]int{},
+	}
+	total := 0.0
+	for i, doc := range docs {
+		freqs := map[string]float64{}
+		add := func(text string, weight float64) {
+			for _, t := range tokenize(text) {
+				freqs[t.text] += weight
+				ix.lengths[i] += weight
+			}
+		}
+		add(doc.Name, nameWeight)
+		add(doc.Description, descriptionWeight)
+		for _, line := range doc.Lines {
+			add(line.Text, lineWeight)
+		}
+		for term := range freqs {
+			ix.docFreq[term]++
+		}
+		ix.freqs[i] = freqs
+		total += ix.lengths[i]
+	}
+	if len(docs) > 0 {
+		ix.avgLength = total / float64(len(docs))
+	}
+	for term := range ix.docFreq {
+		ix.vocabulary = append(ix.vocabulary, term)
+	}
+	sort.Strings(ix.vocabulary)
+	return ix
+}
+
+// Len is the number of indexed documents
+func (ix *Index) Len() int {
+	return len(ix.docs)
+}
+
+// Search returns the documents matching any word of query, best first. A
+// limit of 0 returns every match.
+func (ix *Index) Search(query string, limit int) []Result {
+	terms := queryTerms(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	// Each query term matches itself and the words it starts
+	expansions := make([][]string, len(terms))
+	for i, term := range terms {
+		expansions[i] = ix.withPrefix(term)
+	}
+
+	var results []Result
+	for i := range ix.docs {
+		score := 0.0
+		for j, term := range terms {
+			best := 0.0
+			for _, word := range expansions[j] {
+				s := ix.score(i, word)
+				if word != term {
+					s *= prefixWeight
+				}
+				best = max(best, s)
+			}
+			score += best
+		}
+		if score > 0 {
+			results = append(results, Result{Document: &ix.docs[i], Score: score})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Document.Name < results[j].Document.Name
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	for i := range results {
+		results[i].Snippet = snippet(results[i].Document, terms)
+	}
+	return results
+}
+
+// score is the BM25 score of one term in document i
+func (ix *Index) score(i int, term string) float64 {
+	tf := ix.freqs[i][term]
+	if tf == 0 {
+		return 0
+	}
+	n := float64(len(ix.docs))
+	df := float64(ix.docFreq[term])
+	idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+	norm := 1 - b + b*ix.lengths[i]/ix.avgLength
+	return idf * tf * (k1 + 1) / (tf + k1*norm)
+}
+
+// withPrefix returns the indexed terms that start with prefix
+func (ix *Index) withPrefix(prefix string) []string {
+	start := sort.SearchStrings(ix.vocabulary, prefix)
+	var terms []string
+	for _, term := range ix.vocabulary[start:] {
+		if !strings.HasPrefix(term, prefix) {
+			break
+		}
+		terms = append(terms, term)
+	}
+	return terms
+}
+
+// snippet picks the line matching the most query terms, falling back to
+// the description when only the name matched
+func snippet(doc *Document, terms []string) Snippet {
+	best, bestCount := -1, 0
+	for i, line := range doc.Lines {
+		if count := len(matchedTerms(line.Text, terms)); count > bestCount {
+			best, bestCount = i, count
+		}
+	}
+	if best < 0 {
+		s := Snippet{Text: doc.Description}
+		s.Matches = matches(s.Text, terms)
+		return s.trim()
+	}
+	line := doc.Lines[best]
+	s := Snippet{Line: line.Number, Text: line.Text, Matches: matches(line.Text, terms)}
+	return s.trim()
+}
+
+// matchedTerms returns the distinct query terms that start a word of text
+func matchedTerms(text string, terms []string) map[string]bool {
+	found := map[string]bool{}
+	for _, t := range tokenize(text) {
+		for _, term := range terms {
+			if strings.HasPrefix(t.text, term) {
+				found[term] = true
+			}
+		}
+	}
+	return found
+}
+
+// matches returns the byte ranges of the words of text that query terms
+// start
+func matches(text string, terms []string) [][2]int {
+	var ranges [][2]int
+	for _, t := range tokenize(text) {
+		for _, term := range terms {
+			if strings.HasPrefix(t.text, term) {
+				ranges = append(ranges, [2]int{t.start, t.end})
+				break
+			}
+		}
+	}
+	return ranges
+}
+
+// trim strips surrounding space and cuts long lines to a window that
+// starts shortly before the first match
+func (s Snippet) trim() Snippet {
+	trimmed := strings.TrimLeftFunc(s.Text, unicode.IsSpace)
+	s = s.shift(len(s.Text)-len(trimmed), trimmed)
+	s.Text = strings.TrimRightFunc(s.Text, unicode.IsSpace)
+	if utf8.RuneCountInString(s.Text) <= maxSnippet {
+		return s
+	}
+
+	start := 0
+	if len(s.Matches) > 0 {
+		start = max(0, s.Matches[0][0]-maxSnippet/4)
+		for start > 0 && !utf8.RuneStart(s.Text[start]) {
+			start--
+		}
+	}
+	end := start
+	for n := 0; n < maxSnippet && end < len(s.Text); n++ {
+		_, size := utf8.DecodeRuneInString(s.Text[end:])
+		end += size
+	}
+
+	var kept [][2]int
+	for _, m := range s.Matches {
+		if m[0] >= start && m[1] <= end {
+			kept = append(kept, m)
+		}
+	}
+	text := s.Text[start:end]
+	prefix := ""
+	if start > 0 {
+		prefix = "…"
+	}
+	if end < len(s.Text) {
+		text += "…"
+	}
+	s.Matches = kept
+	return s.shift(start-len(prefix), prefix+text)
+}
+
+// shift replaces Text after dropping n bytes from its start
+func (s Snippet) shift(n int, text string) Snippet {
+	shifted := make([][2]int, 0, len(s.Matches))
+	for _, m := range s.Matches {
+		shifted = append(shifted, [2]int{m[0] - n, m[1] - n})
+	}
+	s.Text, s.Matches = text, shifted
+	return s
+}
+
+// Highlight renders the snippet with each match passed through style
+func (s Snippet) Highlight(style func(string) string) string {
+	var sb strings.Builder
+	pos := 0
+	for _, m := range s.Matches {
+		if m[0] < pos || m[1] > len(s.Text) {
+			continue
+		}
+		sb.WriteString(s.Text[pos:m[0]])
+		sb.WriteString(style(s.Text[m[0]:m[1]]))
+		pos = m[1]
+	}
+	sb.WriteString(s.Text[pos:])
+	return sb.String()
+}
+
+type token struct {
+	text       string
+	start, end int
+}
+
+// tokenize splits text into lowercase words of letters and digits
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text {
+		word := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if word && start < 0 {
+			start = i
+		} else if !word && start >= 0 {
+			tokens = append(tokens, token{strings.ToLower(text[start:i]), start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{strings.ToLower(text[start:]), start, len(text)})
+	}
+	return tokens
+}
+
+// queryTerms returns the distinct words of query
+func queryTerms(query string) []string {
+	var terms []string
+	seen := map[string]bool{}
+	for _, t := range tokenize(query) {
+		if !seen[t.text] {
+			seen[t.text] = true
+			terms = append(terms, t.text)
+		}
+	}
+	return terms
+}
@@ -0,0 +1,98 @@
+// Package settings reads the parts of Claude Code's settings files that
+// turn plugins and project MCP servers on and off
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/utils"
+)
+
+// File is one settings.json
+type File struct {
+	// EnabledPlugins maps name@marketplace to whether the plugin is on
+	EnabledPlugins map[string]bool `json:"enabledPlugins"`
+	// DisabledMcpjsonServers are project MCP servers that were rejected
+	DisabledMcpjsonServers []string `json:"disabledMcpjsonServers"`
+}
+
+// Settings are the settings of every scope, lowest precedence first
+type Settings struct {
+	files []File
+}
+
+// Scopes are the settings scopes, lowest precedence first
+var Scopes = []domain.CapabilityScope{domain.ScopeUser, domain.ScopeProject, domain.ScopeLocal}
+
+// Path returns the settings file of a scope: ~/.claude/settings.json,
+// .claude/settings.json or .claude/settings.local.json
+func Path(scope domain.CapabilityScope) (string, error) {
+	switch scope {
+	case domain.ScopeUser:
+		dir, err := utils.GetUserClaudeDir()
+		return filepath.Join(dir, "settings.json"), err
+	case domain.ScopeProject:
+		dir, err := utils.GetProjectClaudeDir()
+		return filepath.Join(dir, "settings.json"), err
+	case domain.ScopeLocal:
+		dir, err := utils.GetProjectClaudeDir()
+		return filepath.Join(dir, "settings.local.json"), err
+	}
+	return "", fmt.Errorf("unknown scope %q", scope)
+}
+
+// Load reads the settings files that exist. Files that cannot be read are
+// skipped and reported together in the error, so the settings returned are
+// always usable.
+func Load() (*Settings, error) {
+	s := &Settings{}
+	var errs []error
+	for _, scope := range Scopes {
+		path, err := Path(scope)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		var file File
+		if err == nil {
+			err = json.Unmarshal(data, &file)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("reading %s: %w", path, err))
+			continue
+		}
+		s.files = append(s.files, file)
+	}
+	return s, errors.Join(errs...)
+}
+
+// PluginEnabled reports whether a plugin is on. The most specific scope
+// that mentions it decides; plugins no settings mention are on.
+func (s *Settings) PluginEnabled(key string) bool {
+	for _, file := range slices.Backward(s.files) {
+		if enabled, ok := file.EnabledPlugins[key]; ok {
+			return enabled
+		}
+	}
+	return true
+}
+
+// MCPServerDisabled reports whether a project MCP server was rejected
+func (s *Settings) MCPServerDisabled(name string) bool {
+	for _, file := range s.files {
+		if slices.Contains(file.DisabledMcpjsonServers, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+// Package tokens estimates how much of the model context a piece of text
+// takes up. Claude's tokenizer is not public, so the estimate uses a fixed
+// characters-per-token ratio. It is meant for comparing capabilities with
+// each other, not for exact accounting.
+package tokens
+
+import (
+	"encoding/json"
+	"strconv"
+	"unicode/utf8"
+)
+
+// charsPerToken is the usual ratio for English text and JSON
+const charsPerToken = 4
+
+// Estimate returns the approximate token count of text
+func Estimate(text string) int {
+	n := utf8.RuneCountInString(text)
+	return (n + charsPerToken - 1) / charsPerToken
+}
+
+// EstimateJSON returns the approximate token count of v encoded as compact JSON
+func EstimateJSON(v any) int {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return Estimate(string(data))
+}
+
+// Format abbreviates a token count for narrow displays, e.g. 1.2k
+func Format(n int) string {
+	switch {
+	case n < 1000:
+		return strconv.Itoa(n)
+	case n < 10000:
+		return strconv.FormatFloat(float64(n)/1000, 'f', 1, 64) + "k"
+	default:
+		return strconv.Itoa((n+500)/1000) + "k"
+	}
+}
@@ -0,0 +1,275 @@
+// Package trash keeps deleted capabilities so they can be restored. Each
+// entry is a directory holding meta.json and, for files and skill
+// directories, the deleted item itself under "payload".
+package trash
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/utils"
+)
+
+const (
+	metaFile    = "meta.json"
+	payloadName = "payload"
+)
+
+var ErrNotFound = errors.New("not in trash")
+
+// Entry describes one deleted item
+type Entry struct {
+	ID        string                 `json:"id"`
+	Type      domain.CapabilityType  `json:"type"`
+	Name      string                 `json:"name"`
+	Scope     domain.CapabilityScope `json:"scope"`
+	Path      string                 `json:"path"`
+	DeletedAt time.Time              `json:"deletedAt"`
+	// Server is the config entry of a deleted MCP server
+	Server json.RawMessage `json:"server,omitempty"`
+	// Project is the project root a local MCP server belonged to
+	Project string `json:"project,omitempty"`
+}
+
+type Trash struct {
+	dir string
+}
+
+// Open returns the trash in its default location
+func Open() (*Trash, error) {
+	dir, err := utils.GetTrashDir()
+	if err != nil {
+		return nil, err
+	}
+	return &Trash{dir: dir}, nil
+}
+
+func (t *Trash) Dir() string {
+	return t.dir
+}
+
+// AddPath moves the file or directory at path into the trash
+func (t *Trash) AddPath(entry Entry, path string) (*Entry, error) {
+	entry.Path = path
+	dir, err := t.create(&entry)
+	if err != nil {
+		return nil, err
+	}
+	if err := move(path, filepath.Join(dir, payloadName)); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// AddServer records a deleted MCP server's config entry. The caller
+// removes the entry from the config file afterwards.
+func (t *Trash) AddServer(entry Entry, config json.RawMessage) (*Entry, error) {
+	entry.Server = config
+	if _, err := t.create(&entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// Discard drops an entry without restoring it, e.g. when the deletion it
+// recorded failed
+func (t *Trash) Discard(id string) error {
+	return os.RemoveAll(filepath.Join(t.dir, id))
+}
+
+// List returns the entries, newest first
+func (t *Trash) List() ([]Entry, error) {
+	dirs, err := os.ReadDir(t.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []Entry{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []Entry{}
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		entry, err := t.read(dir.Name())
+		if err != nil {
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].DeletedAt.After(entries[j].DeletedAt)
+	})
+	return entries, nil
+}
+
+// Find looks up an entry by ID, or the most recently deleted item with
+// that name. Commands match with or without their namespace.
+func (t *Trash) Find(idOrName string) (*Entry, error) {
+	if entry, err := t.read(idOrName); err == nil {
+		return entry, nil
+	}
+	entries, err := t.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		short := entry.Name[strings.LastIndex(entry.Name, ":")+1:]
+		if entry.Name == idOrName || short == idOrName {
+			return &entry, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, idOrName)
+}
+
+// RestorePath moves a file entry back to where it was deleted from
+func (t *Trash) RestorePath(entry *Entry) error {
+	if entry.Server != nil {
+		return errors.New("MCP servers are restored into their config file")
+	}
+	if _, err := os.Lstat(entry.Path); err == nil {
+		return fmt.Errorf("%s already exists", entry.Path)
+	}
+	if err := os.MkdirAll(filepath.Dir(entry.Path), 0755); err != nil {
+		return err
+	}
+	if err := move(filepath.Join(t.dir, entry.ID, payloadName), entry.Path); err != nil {
+		return err
+	}
+	return t.Discard(entry.ID)
+}
+
+func (t *Trash) read(id string) (*Entry, error) {
+	if id == "" || filepath.Base(id) != id {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	data, err := os.ReadFile(filepath.Join(t.dir, id, metaFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("%s: %w", id, err)
+	}
+	entry.ID = id
+	return &entry, nil
+}
+
+var unsafeIDChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// create makes a fresh entry directory and writes its metadata
+func (t *Trash) create(entry *Entry) (string, error) {
+	if err := os.MkdirAll(t.dir, 0700); err != nil {
+		return "", err
+	}
+	entry.DeletedAt = time.Now().UTC()
+
+	base := fmt.Sprintf("%s-%s-%s", entry.DeletedAt.Format("20060102-150405"), entry.Type,
+		unsafeIDChars.ReplaceAllString(entry.Name, "-"))
+	for i := 1; ; i++ {
+		entry.ID = base
+		if i > 1 {
+			entry.ID = base + "-" + strconv.Itoa(i)
+		}
+		err := os.Mkdir(filepath.Join(t.dir, entry.ID), 0700)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return "", err
+		}
+	}
+
+	dir := filepath.Join(t.dir, entry.ID)
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, metaFile), append(data, '\n'), 0600); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return dir, nil
+}
+
+// move renames src to dst, copying when they are on different devices
+func move(src, dst string) error {
+	err := os.Rename(src, dst)
+	if err == nil {
+		return nil
+	}
+	var linkErr *os.LinkError
+	if !errors.As(err, &linkErr) {
+		return err
+	}
+	if err := copyTree(src, dst); err != nil {
+		os.RemoveAll(dst)
+		return err
+	}
+	return os.RemoveAll(src)
+}
+
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		default:
+			return copyFile(path, target, info.Mode().Perm())
+		}
+	})
+}
+
+func copyFile(src, dst string, mode fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrFileChanged is returned when a file was modified between being read
+// and being written back
+var ErrFileChanged = errors.New("file changed since it was read")
+
+// FileSnapshot records a file's contents at the time it was read so a later
+// write can detect that someone else modified it in the meantime
+type FileSnapshot struct {
+	Path   string
+	Data   []byte
+	Exists bool
+	Mode   os.FileMode
+}
+
+// ReadFileSnapshot reads path, following symlinks so that writes go to the
+// real file. A missing file yields an empty snapshot rather than an error.
+func ReadFileSnapshot(path string) (*FileSnapshot, error) {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return &FileSnapshot{Path: path, Mode: 0644}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileSnapshot{
+		Path:   path,
+		Data:   data,
+		Exists: true,
+		Mode:   info.Mode().Perm(),
+	}, nil
+}
+
+// Unchanged reports whether the file on disk still matches the snapshot
+func (s *FileSnapshot) Unchanged() (bool, error) {
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return !s.Exists, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return s.Exists && bytes.Equal(data, s.Data), nil
+}
+
+// WriteFileAtomic replaces the snapshotted file with data by writing a
+// temporary file next to it and renaming it into place. It refuses to
+// write if the file changed after the snapshot was taken.
+func WriteFileAtomic(snapshot *FileSnapshot, data []byte) error {
+	dir := filepath.Dir(snapshot.Path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(snapshot.Path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, snapshot.Mode); err != nil {
+		return err
+	}
+
+	// Check as late as possible to keep the race window small
+	unchanged, err := snapshot.Unchanged()
+	if err != nil {
+		return err
+	}
+	if !unchanged {
+		return fmt.Errorf("%w: %s", ErrFileChanged, snapshot.Path)
+	}
+
+	return os.Rename(tmpPath, snapshot.Path)
+}
@@ -23,13 +23,18 @@ func GetUserClaudeDir() (string, error) {
 	return filepath.Join(home, ".claude"), nil
 }
 
+// i.e., /path/to/project
+func GetProjectRoot() (string, error) {
+	return os.Getwd()
+}
+
 // i.e., /path/to/project/.claude
 func GetProjectClaudeDir() (string, error) {
-	cwd, err := os.Getwd()
+	root, err := GetProjectRoot()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(cwd, ".claude"), nil
+	return filepath.Join(root, ".claude"), nil
 }
 
 // i.e., ~/.claude/plugins
@@ -85,3 +90,27 @@ func GetProjectInstalledPluginsFile() (string, error) {
 	}
 	return filepath.Join(pluginsDir, "installed_plugins.json"), nil
 }
+
+// e.g., /home/user/.local/share/claudectl/trash
+func GetTrashDir() (string, error) {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, "claudectl", "trash"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", "claudectl", "trash"), nil
+}
+
+// e.g., /home/user/.cache/claudectl
+func GetCacheDir() (string, error) {
+	if cacheHome := os.Getenv("XDG_CACHE_HOME"); cacheHome != "" {
+		return filepath.Join(cacheHome, "claudectl"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".cache", "claudectl"), nil
+}
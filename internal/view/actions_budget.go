@@ -0,0 +1,78 @@
+package view
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/charmbracelet/bubbles/list"
+
+	"claudectl/internal/budget"
+	"claudectl/internal/domain"
+	"claudectl/internal/mcpclient"
+	"claudectl/internal/settings"
+	"claudectl/internal/tokens"
+	"claudectl/internal/viewmodels"
+)
+
+// openBudgetPane shows the estimated context cost of everything loaded
+func (m *Model) openBudgetPane() {
+	memory, err := budget.LoadMemoryFiles()
+	if err != nil {
+		m.setStatus(err.Error(), true)
+		return
+	}
+	capabilities := append(append([]viewmodels.CapabilityViewModel{}, m.userCapabilities...), m.projectCapabilities...)
+	// Unreadable settings are listed on the overview
+	s, _ := settings.Load()
+	report := budget.New(memory, capabilities, s, m.measurements)
+
+	dims := m.calculatePanelDimensions()
+	m.modal = NewBudgetPane(report, m.width-12, dims.panelHeight-12)
+}
+
+// updateTokenTotals shows each panel's estimated context cost in its title
+func (m *Model) updateTokenTotals() {
+	m.userListPanel.SetNote(m.tokenTotal(m.userListPanel.Items()))
+	m.projectListPanel.SetNote(m.tokenTotal(m.projectListPanel.Items()))
+}
+
+func (m *Model) tokenTotal(items []list.Item) string {
+	total, unmeasured := 0, 0
+	for _, item := range items {
+		vm, ok := item.(viewmodels.CapabilityViewModel)
+		if !ok {
+			continue
+		}
+		n, measured := budget.CapabilityTokens(vm, m.measurements)
+		total += n
+		if !measured {
+			unmeasured++
+		}
+	}
+	// Unmeasured MCP servers make the total a lower bound
+	if unmeasured > 0 {
+		return fmt.Sprintf("~%s+ tokens", tokens.Format(total))
+	}
+	return fmt.Sprintf("~%s tokens", tokens.Format(total))
+}
+
+// loadMeasurements reads the cached MCP tool costs
+func (m *Model) loadMeasurements() {
+	measurements, err := budget.LoadMeasurements()
+	if err != nil && m.logger != nil {
+		m.logger.Warn("failed to read MCP measurements", "error", err)
+	}
+	clear(m.measurements)
+	maps.Copy(m.measurements, measurements)
+}
+
+// recordMeasurement caches the tool cost of a server after an inspect
+func (m *Model) recordMeasurement(server *domain.MCPServer, inspection *mcpclient.Inspection) {
+	measurement := budget.FromInspection(server.Name, inspection)
+	m.measurements.Set(server.Scope, server.Name, measurement)
+	if err := budget.Record(server.Scope, server.Name, measurement); err != nil && m.logger != nil {
+		m.logger.Warn("failed to record MCP measurement", "server", server.Name, "error", err)
+	}
+	m.updateTokenTotals()
+	m.refreshOverview()
+}
@@ -0,0 +1,63 @@
+package view
+
+import (
+	"fmt"
+
+	"claudectl/internal/viewmodels"
+)
+
+const (
+	deleteDialog = "delete"
+	renameForm   = "rename"
+)
+
+func (m *Model) selectedCapability() viewmodels.CapabilityViewModel {
+	vm, _ := m.selectedItem().(viewmodels.CapabilityViewModel)
+	return vm
+}
+
+func (m *Model) openDeleteConfirm() {
+	vm := m.selectedCapability()
+	if vm == nil {
+		return
+	}
+
+	message := fmt.Sprintf("Move %s %q (%s) to the trash?\n%s\n\nRestore it with: claudectl trash restore %s",
+		vm.GetType(), vm.FilterValue(), vm.GetScope(), vm.GetFilePath(), vm.FilterValue())
+	m.modal = NewConfirmModal(deleteDialog, fmt.Sprintf("Delete %s", vm.GetType()), message, vm)
+}
+
+func (m *Model) confirmDelete(vm viewmodels.CapabilityViewModel) {
+	entry, err := m.capWriter.Remove(vm)
+	if err != nil {
+		m.setStatus(err.Error(), true)
+		return
+	}
+	m.afterWrite(fmt.Sprintf("Moved %s %q to the trash (%s)", entry.Type, entry.Name, entry.ID), "", entry.Scope)
+}
+
+func (m *Model) openRenameForm() {
+	vm := m.selectedCapability()
+	if vm == nil {
+		return
+	}
+
+	title := fmt.Sprintf("Rename %s (%s)", vm.FilterValue(), vm.GetScope())
+	m.modal = NewFormModal(renameForm, title, vm).
+		AddField("name", "New name", vm.FilterValue())
+}
+
+func (m *Model) submitRename(form *FormModal) error {
+	vm := form.context.(viewmodels.CapabilityViewModel)
+	newName := form.Value("name")
+	if newName == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	name, err := m.capWriter.Rename(vm, newName)
+	if err != nil {
+		return err
+	}
+	m.afterWrite(fmt.Sprintf("Renamed %q to %q", vm.FilterValue(), name), name, vm.GetScope())
+	return nil
+}
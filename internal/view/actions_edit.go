@@ -0,0 +1,54 @@
+package view
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/editor"
+	"claudectl/internal/viewmodels"
+)
+
+// editorDoneMsg is sent when the editor started by openEditor exits
+type editorDoneMsg struct {
+	name  string
+	scope domain.CapabilityScope
+	path  string
+	err   error
+}
+
+// openEditor suspends the program and opens the selected capability in
+// the user's editor
+func (m *Model) openEditor() tea.Cmd {
+	vm, ok := m.selectedItem().(viewmodels.CapabilityViewModel)
+	if !ok {
+		return nil
+	}
+	path, line := editor.Location(vm)
+	if path == "" {
+		m.setStatus(fmt.Sprintf("%s has no file to edit", vm.GetName()), true)
+		return nil
+	}
+
+	cmd, err := editor.Command(path, line)
+	if err != nil {
+		m.setStatus(err.Error(), true)
+		return nil
+	}
+
+	name, scope := vm.FilterValue(), vm.GetScope()
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorDoneMsg{name: name, scope: scope, path: path, err: err}
+	})
+}
+
+// finishEdit reloads what the editor may have changed and keeps the
+// edited item selected
+func (m *Model) finishEdit(msg editorDoneMsg) {
+	if msg.err != nil {
+		m.setStatus(fmt.Sprintf("Editor failed: %v", msg.err), true)
+		return
+	}
+	m.afterWrite(fmt.Sprintf("Reloaded %s", msg.path), msg.name, msg.scope)
+}
@@ -0,0 +1,85 @@
+package view
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newFilterInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.Placeholder = "name or description, scope:local ns:git plugin:NAME"
+	input.CharLimit = 0
+	return input
+}
+
+// startFilter takes keys into the filter shown in the focused list panel
+func (m *Model) startFilter() tea.Cmd {
+	if m.activePanel == DetailPanelFocus {
+		m.activePanel = m.activeList
+	}
+	m.filtering = true
+	cmd := m.filter.Focus()
+	m.syncFilterPrompt()
+	return cmd
+}
+
+// updateFilter edits the filter and refilters both panels as it changes.
+// Enter keeps the filter and returns to the lists; esc clears it.
+func (m *Model) updateFilter(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.clearFilter()
+		m.updateListsForCurrentTab()
+		m.selectFirstInActivePanel()
+		return nil
+	case tea.KeyEnter:
+		m.filtering = false
+		m.filter.Blur()
+		m.syncFilterPrompt()
+		return nil
+	case tea.KeyUp, tea.KeyDown:
+		var cmd tea.Cmd
+		if m.activeList == UserPanel {
+			m.userListPanel, cmd = m.userListPanel.Update(msg)
+		} else {
+			m.projectListPanel, cmd = m.projectListPanel.Update(msg)
+		}
+		m.updateDetailPanel()
+		return cmd
+	}
+
+	query := m.filter.Value()
+	var cmd tea.Cmd
+	m.filter, cmd = m.filter.Update(msg)
+	if m.filter.Value() != query {
+		m.updateListsForCurrentTab()
+		m.selectFirstInActivePanel()
+	}
+	m.syncFilterPrompt()
+	return cmd
+}
+
+// clearFilter empties the filter without refreshing the lists
+func (m *Model) clearFilter() {
+	m.filtering = false
+	m.filter.Blur()
+	m.filter.SetValue("")
+	m.syncFilterPrompt()
+}
+
+// syncFilterPrompt shows the filter under the focused list panel while it
+// is being typed or applied
+func (m *Model) syncFilterPrompt() {
+	prompt := ""
+	if m.filtering || m.filter.Value() != "" {
+		prompt = m.filter.View()
+	}
+	if m.activeList == UserPanel {
+		m.userListPanel.SetPrompt(prompt)
+		m.projectListPanel.SetPrompt("")
+	} else {
+		m.userListPanel.SetPrompt("")
+		m.projectListPanel.SetPrompt(prompt)
+	}
+}
@@ -0,0 +1,342 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/viewmodels"
+)
+
+const (
+	mcpAddForm      = "mcp-add"
+	mcpEditForm     = "mcp-edit"
+	mcpTransferForm = "mcp-transfer"
+	mcpExportForm   = "mcp-export"
+)
+
+// mcpTransfer is the context of a move or copy form
+type mcpTransfer struct {
+	server *domain.MCPServer
+	move   bool
+}
+
+var (
+	scopeOptions     = []string{string(domain.ScopeUser), string(domain.ScopeProject), string(domain.ScopeLocal)}
+	transportOptions = []string{string(domain.TransportStdio), string(domain.TransportHTTP), string(domain.TransportSSE)}
+)
+
+func (m *Model) selectedMCPServer() *domain.MCPServer {
+	vm, ok := m.selectedItem().(*viewmodels.MCPServerViewModel)
+	if !ok {
+		return nil
+	}
+	return vm.Server()
+}
+
+func (m *Model) openMCPAddForm() {
+	scope := string(domain.ScopeUser)
+	if m.activeList == ProjectPanel {
+		scope = string(domain.ScopeProject)
+	}
+
+	form := NewFormModal(mcpAddForm, "Add MCP server", nil).
+		AddField("name", "Name", "").
+		AddChoice("scope", "Scope", scope, scopeOptions)
+	addServerFields(form, domain.NewMCPServer(domain.MCPServerParams{MCPType: string(domain.TransportStdio)}))
+	m.modal = form
+}
+
+func (m *Model) openMCPEditForm() {
+	server := m.selectedMCPServer()
+	if server == nil {
+		return
+	}
+
+	form := NewFormModal(mcpEditForm, fmt.Sprintf("Edit %s (%s)", server.Name, server.Scope), server)
+	addServerFields(form, server)
+	m.modal = form
+}
+
+func (m *Model) openMCPTransferForm(move bool) {
+	server := m.selectedMCPServer()
+	if server == nil {
+		return
+	}
+
+	var targets []string
+	for _, scope := range scopeOptions {
+		if scope != string(server.Scope) {
+			targets = append(targets, scope)
+		}
+	}
+
+	title := fmt.Sprintf("Copy %s (%s)", server.Name, server.Scope)
+	if move {
+		title = fmt.Sprintf("Move %s (%s)", server.Name, server.Scope)
+	}
+	form := NewFormModal(mcpTransferForm, title, mcpTransfer{server: server, move: move}).
+		AddChoice("target", "To scope", targets[0], targets).
+		AddField("name", "Name", server.Name).
+		AddChoice("secrets", "Secrets", secretsDefault(targets[0]), []string{"keep", "extract"}).
+		AddChoice("overwrite", "Overwrite", "no", []string{"no", "yes"})
+	form.OnChange(func(key string) {
+		if key == "target" {
+			form.SetValue("secrets", secretsDefault(form.Value("target")))
+		}
+	})
+	m.modal = form
+}
+
+// secretsDefault is the secrets choice for a target scope
+func secretsDefault(target string) string {
+	if domain.ExtractSecretsByDefault(domain.CapabilityScope(target)) {
+		return "extract"
+	}
+	return "keep"
+}
+
+func (m *Model) submitMCPTransfer(form *FormModal) error {
+	transfer := form.context.(mcpTransfer)
+	scope, err := domain.ParseScope(form.Value("target"))
+	if err != nil {
+		return err
+	}
+	name := form.Value("name")
+	if name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if name != transfer.server.Name {
+		if err := domain.ValidateMCPServerName(name); err != nil {
+			return err
+		}
+	}
+
+	target := transfer.server.Clone()
+	target.Scope = scope
+	target.Name = name
+	var secrets []domain.SecretReference
+	if form.Value("secrets") == "extract" {
+		secrets = target.ReplaceSecrets()
+	}
+
+	overwrite := form.Value("overwrite") == "yes"
+	verb := "Copied"
+	if transfer.move {
+		verb = "Moved"
+		err = m.mcpWriter.Move(*transfer.server, *target, overwrite)
+	} else {
+		err = m.mcpWriter.Copy(*transfer.server, *target, overwrite)
+	}
+	if err != nil {
+		return err
+	}
+
+	m.afterWrite(fmt.Sprintf("%s MCP server %q to %s scope", verb, name, scope), name, scope)
+
+	if len(secrets) > 0 {
+		var b strings.Builder
+		b.WriteString("Hardcoded secrets were replaced with references.\n")
+		if transfer.move {
+			b.WriteString("The original entry is gone; set these before starting Claude Code:\n\n")
+			for _, secret := range secrets {
+				b.WriteString(secret.Export() + "\n")
+			}
+		} else {
+			b.WriteString("Set these variables before starting Claude Code:\n\n")
+			for _, secret := range secrets {
+				fmt.Fprintf(&b, "%s  (%s)\n", secret.Variable, secret.Field)
+			}
+		}
+		m.modal = NewInfoModal("Environment variables needed", b.String())
+	}
+	return nil
+}
+
+func addServerFields(form *FormModal, server *domain.MCPServer) {
+	form.AddChoice("type", "Type", server.MCPType, transportOptions).
+		AddField("command", "Command", server.Command).
+		AddField("args", "Arguments", joinWords(server.Args)).
+		AddField("env", "Env", joinPairs(server.Env, "=", " ")).
+		AddField("url", "URL", server.Url).
+		AddField("headers", "Headers", joinPairs(server.Headers, ": ", "; "))
+}
+
+// applyServerForm copies the form values onto server
+func applyServerForm(form *FormModal, server *domain.MCPServer) error {
+	// Leave an implicit stdio type implicit
+	if server.MCPType != "" || form.Value("type") != string(domain.TransportStdio) {
+		server.MCPType = form.Value("type")
+	}
+	server.Command = form.Value("command")
+	server.Url = form.Value("url")
+
+	args, err := splitWords(form.Value("args"))
+	if err != nil {
+		return fmt.Errorf("arguments: %w", err)
+	}
+	server.Args = args
+
+	envWords, err := splitWords(form.Value("env"))
+	if err != nil {
+		return fmt.Errorf("env: %w", err)
+	}
+	server.Env = nil
+	for _, word := range envWords {
+		key, value, ok := strings.Cut(word, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("env: %q is not KEY=value", word)
+		}
+		if server.Env == nil {
+			server.Env = map[string]string{}
+		}
+		server.Env[key] = value
+	}
+
+	server.Headers = nil
+	for _, header := range strings.Split(form.Value("headers"), ";") {
+		if strings.TrimSpace(header) == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(header, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("headers: %q is not 'Name: value'", strings.TrimSpace(header))
+		}
+		if server.Headers == nil {
+			server.Headers = map[string]string{}
+		}
+		server.Headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+
+	if problems := server.ConfigProblems(); len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func (m *Model) submitMCPForm(form *FormModal) error {
+	switch form.id {
+	case mcpTransferForm:
+		return m.submitMCPTransfer(form)
+
+	case mcpExportForm:
+		return m.submitMCPExport(form)
+
+	case mcpAddForm:
+		name := form.Value("name")
+		if name == "" {
+			return fmt.Errorf("name is required")
+		}
+		if err := domain.ValidateMCPServerName(name); err != nil {
+			return err
+		}
+		scope, err := domain.ParseScope(form.Value("scope"))
+		if err != nil {
+			return err
+		}
+
+		server := domain.NewMCPServer(domain.MCPServerParams{Name: name, Scope: scope, MCPType: form.Value("type")})
+		if err := applyServerForm(form, server); err != nil {
+			return err
+		}
+		if err := m.mcpWriter.Add(*server); err != nil {
+			return err
+		}
+		m.afterWrite(fmt.Sprintf("Added MCP server %q to %s scope", name, scope), name, scope)
+
+	case mcpEditForm:
+		original := form.context.(*domain.MCPServer)
+		updated := original.Clone()
+		if err := applyServerForm(form, updated); err != nil {
+			return err
+		}
+		if err := m.mcpWriter.Update(*original, *updated); err != nil {
+			return err
+		}
+		m.afterWrite(fmt.Sprintf("Updated MCP server %q", updated.Name), updated.Name, updated.Scope)
+	}
+	return nil
+}
+
+// afterWrite reloads everything from disk and selects the changed item
+func (m *Model) afterWrite(status, name string, scope domain.CapabilityScope) {
+	m.modal = nil
+	m.reloadCapabilities()
+	m.selectCapability(name, scope)
+	m.setStatus(status, false)
+}
+
+// splitWords splits s on whitespace, honouring single and double quotes
+func splitWords(s string) ([]string, error) {
+	var words []string
+	var current strings.Builder
+	var quote rune
+	inWord := false
+
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if inWord {
+		words = append(words, current.String())
+	}
+	return words, nil
+}
+
+func joinWords(words []string) string {
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = quoteWord(word)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func quoteWord(word string) string {
+	if word == "" || strings.ContainsAny(word, " \t\"'") {
+		if strings.Contains(word, "'") {
+			return `"` + word + `"`
+		}
+		return "'" + word + "'"
+	}
+	return word
+}
+
+func joinPairs(m map[string]string, sep, join string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pair := k + sep + m[k]
+		if sep == "=" {
+			pair = quoteWord(pair)
+		}
+		pairs = append(pairs, pair)
+	}
+	return strings.Join(pairs, join)
+}
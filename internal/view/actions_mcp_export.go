@@ -0,0 +1,117 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/mcpformats"
+	"claudectl/internal/viewmodels"
+)
+
+func markKey(vm viewmodels.CapabilityViewModel) string {
+	return string(vm.GetScope()) + "/" + vm.GetName()
+}
+
+func (m *Model) isMarked(item list.Item) bool {
+	vm, ok := item.(viewmodels.CapabilityViewModel)
+	return ok && m.marked[markKey(vm)]
+}
+
+// toggleMark marks or unmarks the selected server and moves to the next one
+func (m *Model) toggleMark() {
+	vm, ok := m.selectedItem().(*viewmodels.MCPServerViewModel)
+	if !ok {
+		return
+	}
+	if k := markKey(vm); m.marked[k] {
+		delete(m.marked, k)
+	} else {
+		m.marked[k] = true
+	}
+
+	if m.activeList == UserPanel {
+		m.userListPanel.list.CursorDown()
+	} else {
+		m.projectListPanel.list.CursorDown()
+	}
+	m.updateDetailPanel()
+}
+
+// exportCandidates returns the marked servers, or every server in the active
+// panel when nothing is marked
+func (m *Model) exportCandidates() ([]domain.MCPServer, string) {
+	var marked, panel []domain.MCPServer
+	collect := func(caps []viewmodels.CapabilityViewModel, inPanel bool) {
+		for _, c := range caps {
+			vm, ok := c.(*viewmodels.MCPServerViewModel)
+			if !ok {
+				continue
+			}
+			if m.marked[markKey(vm)] {
+				marked = append(marked, *vm.Server())
+			}
+			if inPanel {
+				panel = append(panel, *vm.Server())
+			}
+		}
+	}
+	collect(m.userCapabilities, m.activeList == UserPanel)
+	collect(m.projectCapabilities, m.activeList == ProjectPanel)
+
+	if len(marked) > 0 {
+		return marked, fmt.Sprintf("%d marked servers", len(marked))
+	}
+	if m.activeList == UserPanel {
+		return panel, fmt.Sprintf("user servers (%d)", len(panel))
+	}
+	return panel, fmt.Sprintf("project servers (%d)", len(panel))
+}
+
+func (m *Model) openMCPExportForm() {
+	servers, label := m.exportCandidates()
+	if len(servers) == 0 {
+		m.setStatus("No MCP servers to export", true)
+		return
+	}
+
+	formats := make([]string, len(mcpformats.ExportFormats))
+	for i, format := range mcpformats.ExportFormats {
+		formats[i] = string(format)
+	}
+
+	m.modal = NewFormModal(mcpExportForm, "Export "+label, servers).
+		AddChoice("format", "Format", "", formats).
+		AddField("file", "File", "").
+		AddChoice("overwrite", "Overwrite", "no", []string{"no", "yes"})
+}
+
+func (m *Model) submitMCPExport(form *FormModal) error {
+	format, err := mcpformats.ParseFormat(form.Value("format"), mcpformats.ExportFormats)
+	if err != nil {
+		return err
+	}
+	path := form.Value("file")
+	if path == "" {
+		path = mcpformats.DefaultFileName(format)
+	}
+
+	servers := form.context.([]domain.MCPServer)
+	exported, err := mcpformats.Export(format, servers)
+	if err != nil {
+		return err
+	}
+	if err := exported.WriteFile(path, form.Value("overwrite") == "yes"); err != nil {
+		return err
+	}
+
+	m.modal = nil
+	clear(m.marked)
+	m.setStatus(fmt.Sprintf("Exported %d MCP server(s) as %s to %s", exported.Servers, format, path), false)
+	if len(exported.Warnings) > 0 {
+		m.modal = NewInfoModal("Export warnings", strings.Join(exported.Warnings, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/mcpclient"
+)
+
+// inspectTimeout bounds a handshake started from the trace pane
+const inspectTimeout = 30 * time.Second
+
+type traceEventMsg struct {
+	trace *mcpclient.Trace
+}
+
+type inspectDoneMsg struct {
+	pane       *TracePane
+	inspection *mcpclient.Inspection
+	err        error
+}
+
+// traceFor returns the trace buffer of a server, forwarding new events to
+// the running program so an open pane updates live
+func (m *Model) traceFor(server *domain.MCPServer) *mcpclient.Trace {
+	key := string(server.Scope) + "/" + server.Name
+	trace := m.traces.For(key)
+	if !m.subscribed[key] && m.program != nil {
+		program := m.program
+		trace.Subscribe(func(mcpclient.Event) {
+			program.Send(traceEventMsg{trace: trace})
+		})
+		m.subscribed[key] = true
+	}
+	return trace
+}
+
+// openTracePane shows the selected server's trace and starts it to capture
+// a fresh handshake
+func (m *Model) openTracePane() tea.Cmd {
+	server := m.selectedMCPServer()
+	if server == nil {
+		return nil
+	}
+
+	dims := m.calculatePanelDimensions()
+	title := fmt.Sprintf("Trace %s (%s)", server.Name, server.Scope)
+	pane := NewTracePane(title, m.traceFor(server), m.width-12, dims.panelHeight-12, server)
+	m.modal = pane
+	return m.inspect(pane)
+}
+
+func (m *Model) inspect(pane *TracePane) tea.Cmd {
+	server := pane.context.(*domain.MCPServer)
+	trace := m.traceFor(server)
+	pane.SetStatus("starting...", false)
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), inspectTimeout)
+		defer cancel()
+		client, inspection, err := mcpclient.Inspect(ctx, *server, trace)
+		if err == nil {
+			client.Close()
+		}
+		return inspectDoneMsg{pane: pane, inspection: inspection, err: err}
+	}
+}
+
+func (m *Model) finishInspect(msg inspectDoneMsg) {
+	if msg.err != nil {
+		msg.pane.SetStatus(msg.err.Error(), true)
+	} else {
+		info := msg.inspection.Info.ServerInfo
+		msg.pane.SetStatus(fmt.Sprintf("%s %s: handshake ok, %d tools", info.Name, info.Version, len(msg.inspection.Tools)), false)
+		m.recordMeasurement(msg.pane.context.(*domain.MCPServer), msg.inspection)
+	}
+	msg.pane.Refresh()
+}
@@ -0,0 +1,58 @@
+package view
+
+import (
+	"fmt"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/scaffold"
+)
+
+const newItemForm = "new-item"
+
+var newItemScopeOptions = []string{string(domain.ScopeUser), string(domain.ScopeProject)}
+
+func (m *Model) openNewItemForm() {
+	kind := m.activeTab.ToCapabilityType()
+	scope := string(domain.ScopeUser)
+	if m.activeList == ProjectPanel {
+		scope = string(domain.ScopeProject)
+	}
+
+	label := "Name"
+	if kind == domain.TypeCommand {
+		label = "[ns:]name"
+	}
+	m.modal = NewFormModal(newItemForm, fmt.Sprintf("New %s", kind), kind).
+		AddField("name", label, "").
+		AddChoice("scope", "Scope", scope, newItemScopeOptions).
+		AddField("description", "Description", "")
+}
+
+func (m *Model) submitNewItem(form *FormModal) error {
+	kind := form.context.(domain.CapabilityType)
+	scope, err := domain.ParseScope(form.Value("scope"))
+	if err != nil {
+		return err
+	}
+	params := scaffold.Params{
+		Kind:        kind,
+		Scope:       scope,
+		Description: form.Value("description"),
+	}
+	params.Namespace, params.Name = scaffold.SplitName(form.Value("name"))
+	if params.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	path, err := scaffold.Create(params)
+	if err != nil {
+		return err
+	}
+
+	name := params.Name
+	if params.Namespace != "" {
+		name = params.Namespace + ":" + params.Name
+	}
+	m.afterWrite(fmt.Sprintf("Created %s", path), name, scope)
+	return nil
+}
@@ -0,0 +1,60 @@
+package view
+
+import (
+	"fmt"
+
+	"claudectl/internal/budget"
+	"claudectl/internal/domain"
+	"claudectl/internal/search"
+	"claudectl/internal/viewmodels"
+)
+
+// openSearchPane indexes every capability, plugin content and memory file
+// and opens the search overlay
+func (m *Model) openSearchPane() {
+	memory, err := budget.LoadMemoryFiles()
+	if err != nil {
+		m.setStatus(err.Error(), true)
+		return
+	}
+	var plugins []domain.Plugin
+	for _, scope := range []domain.CapabilityScope{domain.ScopeUser, domain.ScopeProject} {
+		loaded, err := m.pluginLoader.Load(scope)
+		if err != nil {
+			if m.logger != nil {
+				m.logger.Warn("failed to load plugins for search", "scope", scope, "error", err)
+			}
+			continue
+		}
+		plugins = append(plugins, loaded...)
+	}
+	capabilities := append(append([]viewmodels.CapabilityViewModel{}, m.userCapabilities...), m.projectCapabilities...)
+	index := search.NewIndex(search.Documents(capabilities, plugins, memory))
+
+	dims := m.calculatePanelDimensions()
+	m.modal = NewSearchPane(index, m.width-12, dims.panelHeight-4)
+}
+
+// jumpToResult selects a search result in its tab. What a plugin
+// contributes is shown through the plugin, and memory files, which have no
+// tab, are only located.
+func (m *Model) jumpToResult(result search.Result) {
+	doc := result.Document
+	if doc.Kind == search.KindMemory {
+		m.setStatus(fmt.Sprintf("Memory file %s", result.Location()), false)
+		return
+	}
+	if doc.Plugin != "" {
+		m.switchToTab(PluginsTab)
+		m.selectCapability(doc.Plugin, doc.Scope)
+		m.setStatus(fmt.Sprintf("%s %s is provided by plugin %s (%s)", doc.Kind, doc.Name, doc.Plugin, result.Location()), false)
+		return
+	}
+	tab, ok := tabForType(domain.CapabilityType(doc.Kind))
+	if !ok {
+		return
+	}
+	m.switchToTab(tab)
+	m.selectCapability(doc.Name, doc.Scope)
+	m.setStatus(result.Location(), false)
+}
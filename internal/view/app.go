@@ -1,16 +1,26 @@
 package view
 
 import (
+	"fmt"
+	"slices"
+
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"claudectl/internal/budget"
 	"claudectl/internal/domain"
 	"claudectl/internal/loaders"
+	"claudectl/internal/mcpclient"
+	"claudectl/internal/overview"
+	"claudectl/internal/query"
+	"claudectl/internal/settings"
 	"claudectl/internal/utils"
 	"claudectl/internal/viewmodels"
+	"claudectl/internal/writers"
 )
 
 type PanelType int
@@ -36,6 +46,8 @@ type Model struct {
 	skillLoader   loaders.Loader[domain.Skill]
 	agentLoader   loaders.Loader[domain.Agent]
 	pluginLoader  loaders.Loader[domain.Plugin]
+	mcpWriter     writers.MCPWriter
+	capWriter     writers.CapabilityWriter
 
 	activeTab   TabType
 	activePanel PanelType
@@ -49,9 +61,33 @@ type Model struct {
 	userListPanel    ListPanel
 	projectListPanel ListPanel
 	detailPanel      DetailPanel
+	overviewPanel    OverviewPanel
+
+	// filter fuzzy filters both list panels; filtering is set while it
+	// takes keys
+	filter    textinput.Model
+	filtering bool
+
+	modal     Modal
+	preview   *ArgumentPreview
+	status    string
+	statusErr bool
+
+	// marked holds the MCP servers selected for export, by markKey
+	marked map[string]bool
+
+	traces     *mcpclient.TraceLog
+	subscribed map[string]bool
+
+	// measurements are the cached MCP tool costs for token totals
+	measurements budget.Measurements
 
 	userCapabilities    []viewmodels.CapabilityViewModel
 	projectCapabilities []viewmodels.CapabilityViewModel
+	// loadErrors are the loads that failed, shown on the overview
+	loadErrors []string
+	// problems are the files loaders skipped or only partly read
+	problems *loaders.Problems
 
 	program *tea.Program
 }
@@ -63,6 +99,9 @@ func NewModel(
 	skillLoader loaders.Loader[domain.Skill],
 	agentLoader loaders.Loader[domain.Agent],
 	pluginLoader loaders.Loader[domain.Plugin],
+	problems *loaders.Problems,
+	mcpWriter writers.MCPWriter,
+	capWriter writers.CapabilityWriter,
 ) *Model {
 	model := &Model{
 		logger:        logger,
@@ -71,29 +110,42 @@ func NewModel(
 		skillLoader:   skillLoader,
 		agentLoader:   agentLoader,
 		pluginLoader:  pluginLoader,
-		activeTab:     MCPsTab,
+		problems:      problems,
+		mcpWriter:     mcpWriter,
+		capWriter:     capWriter,
+		activeTab:     OverviewTab,
 		activePanel:   UserPanel,
 		activeList:    UserPanel,
 		width:         DefaultWidth,
 		height:        DefaultHeight,
 		keys:          DefaultKeyMap(),
 		help:          NewStyledHelp(),
+		marked:        map[string]bool{},
+		traces:        mcpclient.NewTraceLog(),
+		subscribed:    map[string]bool{},
+		measurements:  budget.Measurements{},
+		filter:        newFilterInput(),
 	}
 
+	model.keys.updateForTab(model.activeTab)
 	model.loadCapabilities()
 
 	dims := model.calculatePanelDimensions()
 
-	capType := model.activeTab.ToCapabilityType()
-	userItems := model.filterByType(model.userCapabilities, capType)
-	projectItems := model.filterByType(model.projectCapabilities, capType)
+	tab := query.Query{Types: []domain.CapabilityType{model.activeTab.ToCapabilityType()}}
+	userItems := toListItems(query.Filter(model.userCapabilities, tab))
+	projectItems := toListItems(query.Filter(model.projectCapabilities, tab))
 
 	model.userListPanel = NewListPanel(userItems, "User",
 		dims.leftColumnWidth-4, dims.userPanelHeight-4)
 	model.projectListPanel = NewListPanel(projectItems, "Project",
 		dims.leftColumnWidth-4, dims.projectPanelHeight-4)
+	model.userListPanel.SetMarker(model.isMarked)
+	model.projectListPanel.SetMarker(model.isMarked)
 	model.detailPanel = NewDetailPanel(dims.detailWidth-4, dims.panelHeight-4)
+	model.overviewPanel.SetSize(model.width-6, dims.panelHeight-4)
 
+	model.updateTokenTotals()
 	model.updateDetailPanel()
 
 	return model
@@ -120,11 +172,16 @@ func (m *Model) calculatePanelDimensions() panelDimensions {
 }
 
 func (m *Model) loadCapabilities() {
-	loadFromLoader(m.mcpLoader, &m.userCapabilities, &m.projectCapabilities, m.logger)
-	loadFromLoader(m.commandLoader, &m.userCapabilities, &m.projectCapabilities, m.logger)
-	loadFromLoader(m.skillLoader, &m.userCapabilities, &m.projectCapabilities, m.logger)
-	loadFromLoader(m.agentLoader, &m.userCapabilities, &m.projectCapabilities, m.logger)
-	loadFromLoader(m.pluginLoader, &m.userCapabilities, &m.projectCapabilities, m.logger)
+	m.loadErrors = nil
+	m.problems.Reset()
+	loadFromLoader(m.mcpLoader, "MCP servers", m)
+	loadFromLoader(m.commandLoader, "commands", m)
+	loadFromLoader(m.skillLoader, "skills", m)
+	loadFromLoader(m.agentLoader, "agents", m)
+	loadFromLoader(m.pluginLoader, "plugins", m)
+	m.resolveAgentTools()
+	m.loadMeasurements()
+	m.refreshOverview()
 
 	if m.logger != nil {
 		m.logger.Info("loaded capabilities",
@@ -133,12 +190,34 @@ func (m *Model) loadCapabilities() {
 	}
 }
 
-func loadFromLoader[T any](
-	loader loaders.Loader[T],
-	userCaps *[]viewmodels.CapabilityViewModel,
-	projectCaps *[]viewmodels.CapabilityViewModel,
-	logger *utils.Logger,
-) {
+// resolveAgentTools checks agent tools lists against the loaded MCP servers
+func (m *Model) resolveAgentTools() {
+	all := append(append([]viewmodels.CapabilityViewModel{}, m.userCapabilities...), m.projectCapabilities...)
+	servers := []string{}
+	for _, vm := range all {
+		if vm.GetType() == domain.TypeMCP {
+			servers = append(servers, vm.GetName())
+		}
+	}
+	for _, vm := range all {
+		if agent, ok := vm.(*viewmodels.AgentViewModel); ok {
+			agent.ResolveTools(servers)
+		}
+	}
+}
+
+// reloadCapabilities reads every capability from disk again
+func (m *Model) reloadCapabilities() {
+	m.userCapabilities = nil
+	m.projectCapabilities = nil
+	m.loadCapabilities()
+	m.updateListsForCurrentTab()
+}
+
+// loadFromLoader adds what loader finds in each scope to m, recording
+// failed loads of kind
+func loadFromLoader[T any](loader loaders.Loader[T], kind string, m *Model) {
+	logger := m.logger
 	loadScope := func(scope domain.CapabilityScope, caps *[]viewmodels.CapabilityViewModel, scopeName string) {
 		if items, err := loader.Load(scope); err == nil {
 			for _, cap := range items {
@@ -151,32 +230,119 @@ func loadFromLoader[T any](
 				}
 				*caps = append(*caps, vm)
 			}
-		} else if logger != nil {
-			logger.Warn("failed to load "+scopeName+" capabilities", "error", err)
+		} else {
+			m.loadErrors = append(m.loadErrors, fmt.Sprintf("loading %s %s: %v", scopeName, kind, err))
+			if logger != nil {
+				logger.Warn("failed to load "+scopeName+" capabilities", "error", err)
+			}
 		}
 	}
 
-	loadScope(domain.ScopeUser, userCaps, "user")
-	loadScope(domain.ScopeProject, projectCaps, "project")
+	loadScope(domain.ScopeUser, &m.userCapabilities, "user")
+	loadScope(domain.ScopeProject, &m.projectCapabilities, "project")
 }
 
-func (m *Model) updateListsForCurrentTab() {
-	capType := m.activeTab.ToCapabilityType()
-	userItems := m.filterByType(m.userCapabilities, capType)
-	projectItems := m.filterByType(m.projectCapabilities, capType)
+// refreshOverview summarises the loaded capabilities for the overview tab
+func (m *Model) refreshOverview() {
+	s, err := settings.Load()
+	capabilities := append(append([]viewmodels.CapabilityViewModel{}, m.userCapabilities...), m.projectCapabilities...)
+	report := overview.New(capabilities, s, m.measurements)
+	report.Problems = append(report.Problems, m.loadErrors...)
+	report.Problems = append(report.Problems, m.problems.List()...)
+	if err != nil {
+		report.Problems = append(report.Problems, err.Error())
+	}
+	m.overviewPanel.SetReport(report)
+}
 
-	m.userListPanel.SetItems(userItems)
-	m.projectListPanel.SetItems(projectItems)
+// openOverviewEntry opens the tab of the selected overview row and focuses
+// the panel of its scope
+func (m *Model) openOverviewEntry() {
+	entry, ok := m.overviewPanel.Selected()
+	if !ok {
+		return
+	}
+	tab, ok := tabForType(entry.Kind)
+	if !ok {
+		return
+	}
+	m.switchToTab(tab)
+
+	panel := UserPanel
+	if entry.Scope != domain.ScopeUser {
+		panel = ProjectPanel
+	}
+	m.activePanel = panel
+	m.activeList = panel
+	inScope := func(item list.Item) bool {
+		vm, ok := item.(viewmodels.CapabilityViewModel)
+		return ok && vm.GetScope() == entry.Scope
+	}
+	if panel == UserPanel {
+		m.userListPanel.Select(inScope)
+	} else {
+		m.projectListPanel.Select(inScope)
+	}
+	m.syncFilterPrompt()
+	m.updateDetailPanel()
 }
 
-func (m *Model) filterByType(items []viewmodels.CapabilityViewModel, capType domain.CapabilityType) []list.Item {
-	var filtered []list.Item
-	for _, item := range items {
-		if item.GetType() == capType {
-			filtered = append(filtered, item)
+// updateListsForCurrentTab lists the tab's capabilities in both panels.
+// The filter's scope:, ns: and plugin: terms narrow them with a query and
+// the rest of its text fuzzy matches what is left.
+func (m *Model) updateListsForCurrentTab() {
+	q, plugins, text := query.ParseTerms(m.filter.Value())
+	q.Types = []domain.CapabilityType{m.activeTab.ToCapabilityType()}
+	m.setPanelItems(&m.userListPanel, m.userCapabilities, q, plugins, text)
+	m.setPanelItems(&m.projectListPanel, m.projectCapabilities, q, plugins, text)
+	m.updateTokenTotals()
+}
+
+func (m *Model) setPanelItems(panel *ListPanel, capabilities []viewmodels.CapabilityViewModel, q query.Query, plugins []string, text string) {
+	if m.filter.Value() == "" {
+		panel.SetItems(toListItems(query.Filter(capabilities, q)))
+		return
+	}
+
+	// Like list --plugin, a plugin's capabilities are only listed when
+	// asked for
+	if len(plugins) > 0 {
+		var contents []viewmodels.CapabilityViewModel
+		for _, vm := range capabilities {
+			if plugin, ok := vm.(*viewmodels.PluginViewModel); ok && slices.Contains(plugins, plugin.GetName()) {
+				q.PluginDirs = append(q.PluginDirs, plugin.GetFilePath())
+				contents = append(contents, plugin.Contents()...)
+			}
 		}
+		capabilities = append(append([]viewmodels.CapabilityViewModel{}, capabilities...), contents...)
+	}
+	total := len(query.Filter(capabilities, query.Query{Types: q.Types}))
+	if len(plugins) > 0 && len(q.PluginDirs) == 0 {
+		panel.SetFilteredItems(nil, nil, total)
+		return
+	}
+
+	items := toListItems(query.Filter(capabilities, q))
+	var matches map[list.Item][]int
+	if text != "" {
+		items, matches = fuzzyFilter(items, text)
+	}
+	panel.SetFilteredItems(items, matches, total)
+}
+
+func toListItems(capabilities []viewmodels.CapabilityViewModel) []list.Item {
+	items := make([]list.Item, len(capabilities))
+	for i, vm := range capabilities {
+		items[i] = vm
+	}
+	return items
+}
+
+func (m *Model) selectedItem() list.Item {
+	if m.activeList == UserPanel {
+		return m.userListPanel.SelectedItem()
 	}
-	return filtered
+	return m.projectListPanel.SelectedItem()
 }
 
 func (m *Model) updateDetailPanel() {
@@ -202,8 +368,52 @@ func (m *Model) selectFirstInActivePanel() {
 	m.updateDetailPanel()
 }
 
+// selectCapability focuses the panel for scope and selects the named item,
+// falling back to the first item when it no longer exists
+func (m *Model) selectCapability(name string, scope domain.CapabilityScope) {
+	panel := UserPanel
+	if scope != domain.ScopeUser {
+		panel = ProjectPanel
+	}
+	m.activePanel = panel
+	m.activeList = panel
+
+	matches := func(item list.Item) bool {
+		vm, ok := item.(viewmodels.CapabilityViewModel)
+		// Namespaced commands are selected by their qualified name
+		return ok && (vm.GetName() == name || item.FilterValue() == name) && vm.GetScope() == scope
+	}
+	if panel == UserPanel {
+		if !m.userListPanel.Select(matches) {
+			m.userListPanel.SelectFirst()
+		}
+	} else if !m.projectListPanel.Select(matches) {
+		m.projectListPanel.SelectFirst()
+	}
+	m.updateDetailPanel()
+}
+
+// submitForm routes a submitted form to the action that owns it
+func (m *Model) submitForm(form *FormModal) error {
+	switch form.id {
+	case newItemForm:
+		return m.submitNewItem(form)
+	case renameForm:
+		return m.submitRename(form)
+	default:
+		return m.submitMCPForm(form)
+	}
+}
+
+func (m *Model) setStatus(status string, isErr bool) {
+	m.status = status
+	m.statusErr = isErr
+}
+
 func (m *Model) switchToTab(tab TabType) tea.Cmd {
 	m.activeTab = tab
+	m.keys.updateForTab(tab)
+	m.clearFilter()
 	m.updateListsForCurrentTab()
 	m.selectFirstInActivePanel()
 	if m.logger != nil {
@@ -226,29 +436,145 @@ func (m Model) Init() tea.Cmd {
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case modalCancelMsg:
+		m.modal = nil
+		return m, nil
+
+	case formSubmitMsg:
+		if err := m.submitForm(msg.form); err != nil {
+			msg.form.SetError(err)
+		}
+		return m, nil
+
+	case traceEventMsg:
+		if pane, ok := m.modal.(*TracePane); ok && pane.trace == msg.trace {
+			pane.Refresh()
+		}
+		return m, nil
+
+	case inspectDoneMsg:
+		m.finishInspect(msg)
+		return m, nil
+
+	case editorDoneMsg:
+		m.finishEdit(msg)
+		return m, nil
+
+	case traceRerunMsg:
+		return m, m.inspect(msg.pane)
+
+	case searchJumpMsg:
+		m.modal = nil
+		m.jumpToResult(msg.result)
+		return m, nil
+
+	case confirmMsg:
+		m.modal = nil
+		switch msg.modal.id {
+		case deleteDialog:
+			m.confirmDelete(msg.modal.context.(viewmodels.CapabilityViewModel))
+		}
+		return m, nil
+
 	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+
+		if m.modal != nil {
+			return m, m.modal.Update(msg)
+		}
+		if m.preview != nil {
+			return m, m.updatePreview(msg)
+		}
+		if m.filtering {
+			return m, m.updateFilter(msg)
+		}
+
+		m.status = ""
+
 		if key.Matches(msg, m.keys.Quit) {
 			return m, tea.Quit
 		}
 
+		switch {
+		case key.Matches(msg, m.keys.AddMCP):
+			m.openMCPAddForm()
+			return m, nil
+		case key.Matches(msg, m.keys.EditMCP):
+			m.openMCPEditForm()
+			return m, nil
+		case key.Matches(msg, m.keys.Delete):
+			m.openDeleteConfirm()
+			return m, nil
+		case key.Matches(msg, m.keys.Rename):
+			m.openRenameForm()
+			return m, nil
+		case key.Matches(msg, m.keys.MoveMCP):
+			m.openMCPTransferForm(true)
+			return m, nil
+		case key.Matches(msg, m.keys.CopyMCP):
+			m.openMCPTransferForm(false)
+			return m, nil
+		case key.Matches(msg, m.keys.MarkMCP):
+			m.toggleMark()
+			return m, nil
+		case key.Matches(msg, m.keys.ExportMCP):
+			m.openMCPExportForm()
+			return m, nil
+		case key.Matches(msg, m.keys.TraceMCP):
+			return m, m.openTracePane()
+		case key.Matches(msg, m.keys.Budget):
+			m.openBudgetPane()
+			return m, nil
+		case key.Matches(msg, m.keys.Search):
+			m.openSearchPane()
+			return m, nil
+		case key.Matches(msg, m.keys.Filter):
+			return m, m.startFilter()
+		case msg.Type == tea.KeyEsc && m.filter.Value() != "":
+			m.clearFilter()
+			m.updateListsForCurrentTab()
+			m.selectFirstInActivePanel()
+			return m, nil
+		case key.Matches(msg, m.keys.ToggleRaw):
+			if m.detailPanel.ToggleRaw() {
+				m.setStatus("Showing file source", false)
+			} else {
+				m.setStatus("Showing rendered markdown", false)
+			}
+			m.updateDetailPanel()
+			return m, nil
+		case key.Matches(msg, m.keys.PreviewArgs):
+			m.openPreview()
+			return m, nil
+		case key.Matches(msg, m.keys.Edit):
+			return m, m.openEditor()
+		case key.Matches(msg, m.keys.NewItem):
+			m.openNewItemForm()
+			return m, nil
+		}
+
 		if key.Matches(msg, m.keys.Help) {
 			m.help.ShowAll = !m.help.ShowAll
 		}
 
-		tabKeys := []struct {
-			binding key.Binding
-			tab     TabType
-		}{
-			{m.keys.Tab1, MCPsTab},
-			{m.keys.Tab2, CommandsTab},
-			{m.keys.Tab3, SkillsTab},
-			{m.keys.Tab4, PluginsTab},
-			{m.keys.Tab5, AgentsTab},
+		for i, binding := range m.keys.Tabs {
+			if key.Matches(msg, binding) {
+				return m, m.switchToTab(TabType(i))
+			}
 		}
-		for _, tk := range tabKeys {
-			if key.Matches(msg, tk.binding) {
-				return m, m.switchToTab(tk.tab)
+
+		if m.activeTab == OverviewTab {
+			switch {
+			case key.Matches(msg, m.keys.Up):
+				m.overviewPanel.Move(-1)
+			case key.Matches(msg, m.keys.Down):
+				m.overviewPanel.Move(1)
+			case msg.Type == tea.KeyEnter:
+				m.openOverviewEntry()
 			}
+			return m, nil
 		}
 
 		if key.Matches(msg, m.keys.SwitchListPanel) {
@@ -263,6 +589,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.userListPanel.SelectFirst()
 			}
 
+			m.syncFilterPrompt()
 			m.updateDetailPanel()
 			if m.logger != nil {
 				m.logger.Debug("switched panel focus", "panel", m.activePanel)
@@ -315,6 +642,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.userListPanel.SetSize(dims.leftColumnWidth-4, dims.userPanelHeight-4)
 		m.projectListPanel.SetSize(dims.leftColumnWidth-4, dims.projectPanelHeight-4)
 		m.detailPanel.SetSize(dims.detailWidth-4, dims.panelHeight-4)
+		m.overviewPanel.SetSize(m.width-6, dims.panelHeight-4)
 
 		if m.logger != nil {
 			m.logger.Debug("window resized", "width", m.width, "height", m.height)
@@ -366,9 +694,26 @@ func (m Model) View() string {
 		Render(m.detailPanel.View())
 
 	panels := lipgloss.JoinHorizontal(lipgloss.Top, leftColumn, detailPanel)
+	if m.activeTab == OverviewTab {
+		panels = focusedBorderStyle.
+			Width(m.width - 2).
+			Height(dims.panelHeight).
+			Render(m.overviewPanel.View())
+	}
+	if m.modal != nil {
+		panels = lipgloss.Place(m.width, lipgloss.Height(panels), lipgloss.Center, lipgloss.Center, m.modal.View())
+	}
 
 	helpView := m.help.View(m.keys)
 	help := helpStyle.Width(m.width).Render(helpView)
 
+	if m.status != "" {
+		status := statusSuccessStyle.Render(SymbolCheck + " " + m.status)
+		if m.statusErr {
+			status = statusErrorStyle.Render(SymbolCross + " " + m.status)
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, tabBar, panels, statusLineStyle.Render(status), help)
+	}
+
 	return lipgloss.JoinVertical(lipgloss.Left, tabBar, panels, help)
 }
@@ -0,0 +1,102 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/viewmodels"
+)
+
+// ArgumentPreview takes sample arguments for a command and shows its body
+// with them substituted, in place of the plain content in the detail panel
+type ArgumentPreview struct {
+	command *viewmodels.CommandViewModel
+	input   textinput.Model
+}
+
+func NewArgumentPreview(command *viewmodels.CommandViewModel) *ArgumentPreview {
+	input := textinput.New()
+	input.Prompt = SymbolPrompt + " "
+	input.Placeholder = command.ArgumentHint()
+	if input.Placeholder == "" {
+		input.Placeholder = "sample arguments"
+	}
+	input.CharLimit = 0
+	input.Focus()
+	return &ArgumentPreview{command: command, input: input}
+}
+
+// Update handles a key and reports whether the preview should close
+func (p *ArgumentPreview) Update(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if msg.Type == tea.KeyEsc || msg.Type == tea.KeyEnter {
+		return true, nil
+	}
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return false, cmd
+}
+
+// View renders the argument input, problems found and the expanded body
+func (p *ArgumentPreview) View(width int) string {
+	p.input.Width = width - 4
+	expansion := domain.ExpandArguments(p.command.GetContent(), p.input.Value())
+
+	var b strings.Builder
+	b.WriteString(detailSectionHeaderStyle.Render("Arguments"))
+	b.WriteString("\n")
+	b.WriteString(p.input.View())
+	b.WriteString("\n")
+	b.WriteString(modalHintStyle.Render("enter/esc close preview"))
+	b.WriteString("\n")
+
+	for _, n := range expansion.Missing {
+		b.WriteString(statusErrorStyle.Render(fmt.Sprintf("%s $%d has no argument", SymbolCross, n)))
+		b.WriteString("\n")
+	}
+	for _, n := range expansion.Unreferenced {
+		message := fmt.Sprintf("%s argument %d (%q) is never used", SymbolWarning, n, expansion.Args[n-1])
+		b.WriteString(statusWarningStyle.Render(message))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderDivider(width))
+	b.WriteString("\n\n")
+	for _, part := range expansion.Parts {
+		switch part.Kind {
+		case domain.PartArgument:
+			b.WriteString(argumentStyle.Render(part.Text))
+		case domain.PartMissing:
+			b.WriteString(missingArgumentStyle.Render(part.Placeholder))
+		case domain.PartAppended:
+			b.WriteString(traceInfoStyle.Render(part.Text))
+		default:
+			b.WriteString(part.Text)
+		}
+	}
+	return b.String()
+}
+
+func (m *Model) openPreview() {
+	command, ok := m.selectedItem().(*viewmodels.CommandViewModel)
+	if !ok {
+		return
+	}
+	m.preview = NewArgumentPreview(command)
+	m.detailPanel.SetPreview(m.preview)
+	m.updateDetailPanel()
+}
+
+func (m *Model) updatePreview(msg tea.KeyMsg) tea.Cmd {
+	done, cmd := m.preview.Update(msg)
+	if done {
+		m.preview = nil
+		m.detailPanel.SetPreview(nil)
+	}
+	m.updateDetailPanel()
+	return cmd
+}
@@ -0,0 +1,90 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"claudectl/internal/budget"
+	"claudectl/internal/domain"
+	"claudectl/internal/tokens"
+)
+
+// BudgetPane lists what each capability and memory file costs in context,
+// most expensive first
+type BudgetPane struct {
+	report   *budget.Report
+	viewport viewport.Model
+}
+
+func NewBudgetPane(report *budget.Report, width, height int) *BudgetPane {
+	p := &BudgetPane{report: report, viewport: viewport.New(width, height)}
+	p.viewport.SetContent(p.render())
+	return p
+}
+
+func (p *BudgetPane) render() string {
+	if len(p.report.Items) == 0 {
+		return emptyStateStyle.Render("Nothing is loaded into the context")
+	}
+
+	var b strings.Builder
+	b.WriteString(detailSectionHeaderStyle.UnsetPadding().Render(fmt.Sprintf("%8s  %-8s  %-8s  %-8s  %s", "~TOKENS", "KIND", "SCOPE", "LOADED", "NAME")))
+	b.WriteString("\n")
+	for _, item := range p.report.Items {
+		cost := fmt.Sprint(item.Tokens)
+		style := detailValueStyle
+		if !item.Measured {
+			cost, style = "?", traceInfoStyle
+		}
+		b.WriteString(style.Render(fmt.Sprintf("%8s  %-8s  %-8s  %-8s  %s", cost, item.Kind, item.Scope, item.Loading, item.Label())))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// summary is the total with per-scope totals
+func (p *BudgetPane) summary() string {
+	parts := []string{fmt.Sprintf("~%s tokens", tokens.Format(p.report.Total))}
+	for _, scope := range []domain.CapabilityScope{domain.ScopeUser, domain.ScopeProject, domain.ScopeLocal} {
+		if n, ok := p.report.Scopes[scope]; ok {
+			parts = append(parts, fmt.Sprintf("%s ~%s", scope, tokens.Format(n)))
+		}
+	}
+	summary := strings.Join(parts, " • ")
+	if p.report.Unmeasured > 0 {
+		summary += fmt.Sprintf("\n%d MCP server(s) unmeasured: press t on one or run claudectl budget --measure", p.report.Unmeasured)
+	}
+	return summary
+}
+
+func (p *BudgetPane) Update(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "q", "b":
+		return cancelModal
+	case "g", "home":
+		p.viewport.GotoTop()
+		return nil
+	case "G", "end":
+		p.viewport.GotoBottom()
+		return nil
+	}
+	var cmd tea.Cmd
+	p.viewport, cmd = p.viewport.Update(msg)
+	return cmd
+}
+
+func (p *BudgetPane) View() string {
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		modalTitleStyle.Render("Context budget"),
+		modalHintStyle.Render(p.summary()),
+		"",
+		p.viewport.View(),
+		"",
+		modalHintStyle.Render(fmt.Sprintf("↑/↓ scroll • g/G top/bottom • esc close • %d%%", int(p.viewport.ScrollPercent()*100))),
+	)
+	return modalStyle.Render(body)
+}
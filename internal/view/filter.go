@@ -0,0 +1,65 @@
+package view
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/sahilm/fuzzy"
+
+	"claudectl/internal/viewmodels"
+)
+
+// fuzzyFilter returns the items whose name, namespace or description fuzzy
+// match query, best first. Name matches rank above description matches,
+// and matches holds the rune indexes to highlight in each item's
+// FilterValue.
+func fuzzyFilter(items []list.Item, query string) (filtered []list.Item, matches map[list.Item][]int) {
+	names := make([]string, len(items))
+	descriptions := make([]string, len(items))
+	for i, item := range items {
+		// FilterValue qualifies commands with their namespace
+		names[i] = item.FilterValue()
+		if vm, ok := item.(viewmodels.CapabilityViewModel); ok {
+			descriptions[i] = vm.GetDescription()
+		}
+	}
+
+	scores := map[int]int{}
+	matches = map[list.Item][]int{}
+	for _, match := range fuzzy.Find(query, names) {
+		scores[match.Index] = match.Score
+		matches[items[match.Index]] = runeIndexes(names[match.Index], match.MatchedIndexes)
+	}
+	// Description matches sort after every name match
+	const descriptionPenalty = 1 << 20
+	for _, match := range fuzzy.Find(query, descriptions) {
+		if _, ok := scores[match.Index]; !ok {
+			scores[match.Index] = match.Score - descriptionPenalty
+		}
+	}
+
+	indexes := make([]int, 0, len(scores))
+	for i := range scores {
+		indexes = append(indexes, i)
+	}
+	sort.Slice(indexes, func(a, b int) bool {
+		if scores[indexes[a]] != scores[indexes[b]] {
+			return scores[indexes[a]] > scores[indexes[b]]
+		}
+		return indexes[a] < indexes[b]
+	})
+	for _, i := range indexes {
+		filtered = append(filtered, items[i])
+	}
+	return filtered, matches
+}
+
+// runeIndexes converts byte offsets into s to rune offsets
+func runeIndexes(s string, offsets []int) []int {
+	runes := make([]int, len(offsets))
+	for i, offset := range offsets {
+		runes[i] = utf8.RuneCountInString(s[:offset])
+	}
+	return runes
+}
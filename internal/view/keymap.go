@@ -1,6 +1,10 @@
 package view
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/key"
+)
 
 type KeyMap struct {
 	Up       key.Binding
@@ -12,11 +16,27 @@ type KeyMap struct {
 
 	SwitchListPanel key.Binding
 
-	Tab1 key.Binding
-	Tab2 key.Binding
-	Tab3 key.Binding
-	Tab4 key.Binding
-	Tab5 key.Binding
+	// Tabs select each tab by its number
+	Tabs []key.Binding
+
+	AddMCP    key.Binding
+	EditMCP   key.Binding
+	MoveMCP   key.Binding
+	CopyMCP   key.Binding
+	MarkMCP   key.Binding
+	ExportMCP key.Binding
+	TraceMCP  key.Binding
+
+	Edit    key.Binding
+	NewItem key.Binding
+	Delete  key.Binding
+	Rename  key.Binding
+
+	PreviewArgs key.Binding
+	Budget      key.Binding
+	ToggleRaw   key.Binding
+	Search      key.Binding
+	Filter      key.Binding
 
 	Help key.Binding
 	Quit key.Binding
@@ -44,25 +64,70 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("tab"),
 			key.WithHelp("tab", "switch panels"),
 		),
-		Tab1: key.NewBinding(
-			key.WithKeys("1"),
-			key.WithHelp("", ""),
+		Tabs: tabKeys(),
+		AddMCP: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "add server"),
 		),
-		Tab2: key.NewBinding(
-			key.WithKeys("2"),
-			key.WithHelp("", ""),
+		EditMCP: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "edit server"),
 		),
-		Tab3: key.NewBinding(
-			key.WithKeys("3"),
-			key.WithHelp("", ""),
+		MoveMCP: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "move to scope"),
 		),
-		Tab4: key.NewBinding(
-			key.WithKeys("4"),
-			key.WithHelp("", ""),
+		CopyMCP: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "copy to scope"),
 		),
-		Tab5: key.NewBinding(
-			key.WithKeys("5"),
-			key.WithHelp("", ""),
+		MarkMCP: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "mark"),
+		),
+		ExportMCP: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "export"),
+		),
+		TraceMCP: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "trace"),
+		),
+		Edit: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "open in editor"),
+		),
+		NewItem: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "new"),
+		),
+		Delete: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "delete"),
+		),
+		Rename: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "rename"),
+		),
+		PreviewArgs: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "preview arguments"),
+		),
+		Budget: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "context budget"),
+		),
+		ToggleRaw: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "raw/rendered"),
+		),
+		Search: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "search everything"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
 		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
@@ -75,6 +140,18 @@ func DefaultKeyMap() KeyMap {
 	}
 }
 
+// tabKeys binds 1, 2, ... to the tabs in order
+func tabKeys() []key.Binding {
+	bindings := make([]key.Binding, TabCount)
+	for i := range bindings {
+		bindings[i] = key.NewBinding(
+			key.WithKeys(strconv.Itoa(i+1)),
+			key.WithHelp("", ""),
+		)
+	}
+	return bindings
+}
+
 func (k KeyMap) ShortHelp() []key.Binding {
 	return []key.Binding{k.Help, k.Quit}
 }
@@ -87,6 +164,26 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 		},
 		{
 			k.SwitchListPanel,
+			k.Filter,
+		},
+		{
+			k.AddMCP,
+			k.EditMCP,
+			k.MoveMCP,
+			k.CopyMCP,
+			k.MarkMCP,
+			k.ExportMCP,
+			k.TraceMCP,
+		},
+		{
+			k.Edit,
+			k.NewItem,
+			k.Delete,
+			k.Rename,
+			k.PreviewArgs,
+			k.Budget,
+			k.ToggleRaw,
+			k.Search,
 		},
 		{
 			k.Help,
@@ -94,3 +191,23 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 		},
 	}
 }
+
+// updateForTab enables the bindings that only apply to some tabs
+func (k *KeyMap) updateForTab(tab TabType) {
+	mcp := tab == MCPsTab
+	k.AddMCP.SetEnabled(mcp)
+	k.EditMCP.SetEnabled(mcp)
+	k.MoveMCP.SetEnabled(mcp)
+	k.CopyMCP.SetEnabled(mcp)
+	k.MarkMCP.SetEnabled(mcp)
+	k.ExportMCP.SetEnabled(mcp)
+	k.TraceMCP.SetEnabled(mcp)
+	files := tab == CommandsTab || tab == SkillsTab || tab == AgentsTab
+	k.NewItem.SetEnabled(files)
+	k.Delete.SetEnabled(mcp || files)
+	k.Rename.SetEnabled(mcp || files)
+	k.PreviewArgs.SetEnabled(tab == CommandsTab)
+	k.ToggleRaw.SetEnabled(files)
+	k.Filter.SetEnabled(tab != OverviewTab)
+	k.Edit.SetEnabled(tab != OverviewTab)
+}
@@ -0,0 +1,248 @@
+package view
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Modal is an overlay that captures keyboard input until the model closes it
+type Modal interface {
+	Update(msg tea.KeyMsg) tea.Cmd
+	View() string
+}
+
+type modalCancelMsg struct{}
+
+type formSubmitMsg struct {
+	form *FormModal
+}
+
+type confirmMsg struct {
+	modal *ConfirmModal
+}
+
+func cancelModal() tea.Msg {
+	return modalCancelMsg{}
+}
+
+type formField struct {
+	key     string
+	label   string
+	input   textinput.Model
+	options []string
+}
+
+// FormModal is a small form of labelled text inputs. Fields with options
+// cycle through a fixed set of values instead of accepting free text.
+type FormModal struct {
+	id      string
+	title   string
+	fields  []formField
+	focus   int
+	err     string
+	context any
+	// changed is called with a choice's key after it cycles
+	changed func(key string)
+}
+
+func NewFormModal(id, title string, context any) *FormModal {
+	return &FormModal{id: id, title: title, context: context}
+}
+
+// AddField appends a free-text field
+func (f *FormModal) AddField(key, label, value string) *FormModal {
+	input := textinput.New()
+	input.Prompt = ""
+	input.CharLimit = 0
+	input.Width = modalInputWidth
+	input.SetValue(value)
+	f.fields = append(f.fields, formField{key: key, label: label, input: input})
+	f.refocus()
+	return f
+}
+
+// AddChoice appends a field that cycles through options with left/right
+func (f *FormModal) AddChoice(key, label, value string, options []string) *FormModal {
+	f.AddField(key, label, value)
+	f.fields[len(f.fields)-1].options = options
+	if value == "" && len(options) > 0 {
+		f.fields[len(f.fields)-1].input.SetValue(options[0])
+	}
+	return f
+}
+
+func (f *FormModal) Value(key string) string {
+	for _, field := range f.fields {
+		if field.key == key {
+			return strings.TrimSpace(field.input.Value())
+		}
+	}
+	return ""
+}
+
+// SetValue replaces the value of a field
+func (f *FormModal) SetValue(key, value string) {
+	for i := range f.fields {
+		if f.fields[i].key == key {
+			f.fields[i].input.SetValue(value)
+		}
+	}
+}
+
+// OnChange registers fn to run whenever a choice field changes, so other
+// fields can follow it
+func (f *FormModal) OnChange(fn func(key string)) {
+	f.changed = fn
+}
+
+func (f *FormModal) SetError(err error) {
+	f.err = ""
+	if err != nil {
+		f.err = err.Error()
+	}
+}
+
+func (f *FormModal) Update(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		return cancelModal
+	case "enter":
+		return func() tea.Msg { return formSubmitMsg{form: f} }
+	case "tab", "down":
+		f.focus = (f.focus + 1) % len(f.fields)
+		f.refocus()
+		return nil
+	case "shift+tab", "up":
+		f.focus = (f.focus - 1 + len(f.fields)) % len(f.fields)
+		f.refocus()
+		return nil
+	}
+
+	field := &f.fields[f.focus]
+	if len(field.options) > 0 {
+		switch msg.String() {
+		case "left", "right", " ":
+			field.input.SetValue(cycleOption(field.options, field.input.Value(), msg.String() == "left"))
+			if f.changed != nil {
+				f.changed(field.key)
+			}
+		}
+		return nil
+	}
+
+	var cmd tea.Cmd
+	field.input, cmd = field.input.Update(msg)
+	return cmd
+}
+
+func (f *FormModal) refocus() {
+	for i := range f.fields {
+		if i == f.focus {
+			f.fields[i].input.Focus()
+		} else {
+			f.fields[i].input.Blur()
+		}
+	}
+}
+
+func (f *FormModal) View() string {
+	var b strings.Builder
+	b.WriteString(modalTitleStyle.Render(f.title))
+	b.WriteString("\n\n")
+
+	for i, field := range f.fields {
+		label := formLabelStyle.Render(field.label)
+		if i == f.focus {
+			label = formFocusedLabelStyle.Render(field.label)
+		}
+		value := field.input.View()
+		if len(field.options) > 0 {
+			value = "‹ " + field.input.Value() + " ›"
+		}
+		b.WriteString(label + " " + value + "\n")
+	}
+
+	if f.err != "" {
+		b.WriteString("\n" + statusErrorStyle.Render(SymbolCross+" "+f.err) + "\n")
+	}
+
+	b.WriteString("\n" + modalHintStyle.Render("enter save • tab next field • esc cancel"))
+	return modalStyle.Render(b.String())
+}
+
+func cycleOption(options []string, current string, backwards bool) string {
+	idx := 0
+	for i, option := range options {
+		if option == current {
+			idx = i
+			break
+		}
+	}
+	if backwards {
+		idx = (idx - 1 + len(options)) % len(options)
+	} else {
+		idx = (idx + 1) % len(options)
+	}
+	return options[idx]
+}
+
+// ConfirmModal asks a yes/no question before a destructive action
+type ConfirmModal struct {
+	id      string
+	title   string
+	message string
+	context any
+}
+
+func NewConfirmModal(id, title, message string, context any) *ConfirmModal {
+	return &ConfirmModal{id: id, title: title, message: message, context: context}
+}
+
+func (c *ConfirmModal) Update(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "y", "Y", "enter":
+		return func() tea.Msg { return confirmMsg{modal: c} }
+	case "n", "N", "esc":
+		return cancelModal
+	}
+	return nil
+}
+
+func (c *ConfirmModal) View() string {
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		modalTitleStyle.Render(c.title),
+		"",
+		detailValueStyle.Render(c.message),
+		"",
+		modalHintStyle.Render("y confirm • n cancel"),
+	)
+	return modalStyle.Render(body)
+}
+
+// InfoModal shows a block of text until any key is pressed
+type InfoModal struct {
+	title string
+	body  string
+}
+
+func NewInfoModal(title, body string) *InfoModal {
+	return &InfoModal{title: title, body: body}
+}
+
+func (i *InfoModal) Update(msg tea.KeyMsg) tea.Cmd {
+	return cancelModal
+}
+
+func (i *InfoModal) View() string {
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		modalTitleStyle.Render(i.title),
+		"",
+		detailValueStyle.Render(i.body),
+		"",
+		modalHintStyle.Render("press any key to close"),
+	)
+	return modalStyle.Render(body)
+}
@@ -0,0 +1,149 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+
+	"claudectl/internal/overview"
+	"claudectl/internal/tokens"
+)
+
+// overviewHeaderLines is how many lines come before the first entry
+const overviewHeaderLines = 2
+
+// OverviewPanel is the overview tab: a row per capability type and scope,
+// then plugin updates and load problems. Enter on a row opens its tab.
+type OverviewPanel struct {
+	report   *overview.Report
+	cursor   int
+	viewport viewport.Model
+}
+
+func (p *OverviewPanel) SetSize(width, height int) {
+	p.viewport.Width = width
+	p.viewport.Height = height
+	p.refresh()
+}
+
+// SetReport shows report, keeping the cursor on the same row
+func (p *OverviewPanel) SetReport(report *overview.Report) {
+	p.report = report
+	p.cursor = min(p.cursor, max(0, len(report.Entries)-1))
+	p.refresh()
+}
+
+// Move moves the cursor and scrolls to keep it visible
+func (p *OverviewPanel) Move(delta int) {
+	if p.report == nil || len(p.report.Entries) == 0 {
+		return
+	}
+	p.cursor = max(0, min(len(p.report.Entries)-1, p.cursor+delta))
+	p.refresh()
+
+	line := overviewHeaderLines + p.cursor
+	if line < p.viewport.YOffset {
+		p.viewport.SetYOffset(line)
+	} else if line >= p.viewport.YOffset+p.viewport.Height {
+		p.viewport.SetYOffset(line - p.viewport.Height + 1)
+	}
+}
+
+// Selected returns the entry under the cursor
+func (p *OverviewPanel) Selected() (overview.Entry, bool) {
+	if p.report == nil || p.cursor >= len(p.report.Entries) {
+		return overview.Entry{}, false
+	}
+	return p.report.Entries[p.cursor], true
+}
+
+func (p *OverviewPanel) refresh() {
+	if p.report == nil {
+		return
+	}
+	p.viewport.SetContent(p.render())
+}
+
+func (p *OverviewPanel) render() string {
+	var b strings.Builder
+	b.WriteString(detailSectionHeaderStyle.UnsetPadding().Padding(0, 1).Render(overviewRow("TYPE", "SCOPE", "COUNT", "SIZE", "~TOKENS", "ENABLED", "WARNINGS")))
+	b.WriteString("\n\n")
+
+	var updates []string
+	for i, entry := range p.report.Entries {
+		tab, _ := tabForType(entry.Kind)
+		cost := tokens.Format(entry.Tokens)
+		if entry.Unmeasured > 0 {
+			cost += "+"
+		}
+		enabled := "-"
+		if entry.Toggleable() && entry.Count > 0 {
+			enabled = fmt.Sprintf("%d/%d", entry.Enabled, entry.Count)
+		}
+		warnings := ""
+		if entry.Warnings > 0 {
+			warnings = fmt.Sprintf("%s %d", SymbolWarning, entry.Warnings)
+		}
+		row := overviewRow(tab.String(), string(entry.Scope), fmt.Sprint(entry.Count), formatBytes(entry.Bytes), cost, enabled, warnings)
+
+		style := unselectedItemStyle
+		if i == p.cursor {
+			style = selectedItemStyle
+		}
+		b.WriteString(style.UnsetMargins().Render(row))
+		b.WriteString("\n")
+
+		for _, update := range entry.Updates {
+			updates = append(updates, fmt.Sprintf("%s %s → %s (%s)", update.Name, update.Installed, update.Latest, entry.Scope))
+		}
+	}
+
+	b.WriteString(detailSectionHeaderStyle.Render("Plugin updates"))
+	b.WriteString("\n")
+	if len(updates) == 0 {
+		b.WriteString(traceInfoStyle.Render("Every plugin is at its marketplace version"))
+		b.WriteString("\n")
+	}
+	for _, update := range updates {
+		b.WriteString(statusInfoStyle.Render(SymbolArrow + " " + update))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(detailSectionHeaderStyle.Render("Problems"))
+	b.WriteString("\n")
+	if len(p.report.Problems) == 0 {
+		b.WriteString(statusSuccessStyle.Render(SymbolCheck + " Everything loaded"))
+		b.WriteString("\n")
+	}
+	for _, problem := range p.report.Problems {
+		b.WriteString(statusErrorStyle.Render(SymbolCross + " " + problem))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(modalHintStyle.Render("↑/↓ select • enter open tab"))
+	return b.String()
+}
+
+func overviewRow(kind, scope, count, size, cost, enabled, warnings string) string {
+	return fmt.Sprintf("%-10s %-8s %6s %9s %8s %8s  %s", kind, scope, count, size, cost, enabled, warnings)
+}
+
+// formatBytes formats a size in B, KB or MB
+func formatBytes(n int) string {
+	switch {
+	case n == 0:
+		return "-"
+	case n < 1024:
+		return fmt.Sprintf("%d B", n)
+	case n < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	}
+}
+
+func (p OverviewPanel) View() string {
+	return p.viewport.View()
+}
@@ -1,6 +1,7 @@
 package view
 
 import (
+	"os"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/viewport"
@@ -8,12 +9,19 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/reflow/wordwrap"
 
+	"claudectl/internal/loaders"
+	"claudectl/internal/markdown"
 	"claudectl/internal/viewmodels"
 )
 
 type DetailPanel struct {
 	viewport viewport.Model
 	ready    bool
+	// preview replaces the content of the command it belongs to
+	preview *ArgumentPreview
+	// raw shows the file source instead of rendered markdown
+	raw      bool
+	markdown markdown.Renderer
 }
 
 func NewDetailPanel(width, height int) DetailPanel {
@@ -83,17 +91,108 @@ func (dp *DetailPanel) Render(item interface{}) {
 		}
 	}
 
+	// Warnings section
+	if wp, ok := vm.(viewmodels.WarningProvider); ok {
+		if warnings := wp.Warnings(); len(warnings) > 0 {
+			b.WriteString("\n")
+			b.WriteString(detailSectionHeaderStyle.Render("Warnings"))
+			b.WriteString("\n")
+			for _, warning := range warnings {
+				b.WriteString(statusWarningStyle.Render(SymbolWarning + " " + warning))
+				b.WriteString("\n")
+			}
+		}
+	}
+
 	// Content section with clean divider
-	if content := vm.GetContent(); content != "" {
+	if dp.preview != nil && item == dp.preview.command {
+		b.WriteString("\n")
+		b.WriteString(dp.preview.View(dp.viewport.Width))
+	} else if content := vm.GetContent(); content != "" {
+		source := readSource(vm)
+		if !dp.raw {
+			dp.renderFrontmatter(&b, source)
+		}
 		b.WriteString("\n")
 		b.WriteString(RenderDivider(dp.viewport.Width))
 		b.WriteString("\n\n")
-		b.WriteString(content)
+		switch {
+		case dp.raw && source != nil:
+			b.WriteString(string(source))
+		case dp.raw:
+			b.WriteString(content)
+		default:
+			b.WriteString(dp.renderMarkdown(content))
+		}
 	}
 
 	dp.SetContent(b.String())
 }
 
+// ToggleRaw switches the content between rendered markdown and the file
+// source, and reports whether the source is now shown
+func (dp *DetailPanel) ToggleRaw() bool {
+	dp.raw = !dp.raw
+	return dp.raw
+}
+
+// readSource reads a markdown capability's file, frontmatter included
+func readSource(vm viewmodels.CapabilityViewModel) []byte {
+	path := vm.GetFilePath()
+	if !strings.HasSuffix(path, ".md") {
+		return nil
+	}
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	return source
+}
+
+// renderFrontmatter writes the frontmatter of source as a key/value table
+func (dp *DetailPanel) renderFrontmatter(b *strings.Builder, source []byte) {
+	frontmatter, _ := loaders.SplitFrontmatter(source)
+	if frontmatter == nil {
+		return
+	}
+
+	b.WriteString("\n")
+	b.WriteString(detailSectionHeaderStyle.Render("Frontmatter"))
+	b.WriteString("\n")
+
+	fields, err := markdown.Fields(frontmatter)
+	if err != nil {
+		b.WriteString(statusErrorStyle.Render(SymbolCross + " invalid YAML: " + err.Error()))
+		b.WriteString("\n")
+		return
+	}
+	width := 0
+	for _, field := range fields {
+		width = max(width, lipgloss.Width(field.Key))
+	}
+	keyStyle := formLabelStyle.Width(width + 2)
+	for _, field := range fields {
+		b.WriteString(keyStyle.Render(field.Key))
+		b.WriteString(detailValueStyle.Render(field.Value))
+		b.WriteString("\n")
+	}
+}
+
+// renderMarkdown renders content for the panel width, falling back to the
+// plain text if rendering fails
+func (dp *DetailPanel) renderMarkdown(content string) string {
+	rendered, err := dp.markdown.Render(content, dp.viewport.Width)
+	if err != nil {
+		return content
+	}
+	return rendered
+}
+
+// SetPreview shows an argument preview, or the plain content when nil
+func (dp *DetailPanel) SetPreview(preview *ArgumentPreview) {
+	dp.preview = preview
+}
+
 func (dp *DetailPanel) Update(msg tea.Msg) tea.Cmd {
 	var cmd tea.Cmd
 	dp.viewport, cmd = dp.viewport.Update(msg)
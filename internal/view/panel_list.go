@@ -5,11 +5,20 @@ import (
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 type ListPanel struct {
 	list  list.Model
 	title string
+	// note follows the item count in the title
+	note string
+	// total is the item count before filtering, or -1 when unfiltered
+	total  int
+	marked func(list.Item) bool
+	// prompt is the filter input shown under the list
+	prompt string
+	height int
 }
 
 func NewListPanel(items []list.Item, title string, width, height int) ListPanel {
@@ -20,8 +29,10 @@ func NewListPanel(items []list.Item, title string, width, height int) ListPanel
 	l.SetShowHelp(false)
 
 	return ListPanel{
-		list:  l,
-		title: title,
+		list:   l,
+		title:  title,
+		total:  -1,
+		height: height,
 	}
 }
 
@@ -32,23 +43,79 @@ func (lp ListPanel) Update(msg tea.Msg) (ListPanel, tea.Cmd) {
 }
 
 func (lp ListPanel) View() string {
-	return lp.list.View()
+	if lp.prompt == "" {
+		return lp.list.View()
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lp.list.View(), lp.prompt)
 }
 
 func (lp *ListPanel) SetSize(width, height int) {
+	lp.height = height
 	lp.list.SetWidth(width)
-	lp.list.SetHeight(height)
+	lp.resize()
+}
+
+// resize fits the list in the panel height, leaving a line for the prompt
+func (lp *ListPanel) resize() {
+	height := lp.height
+	if lp.prompt != "" {
+		height--
+	}
+	lp.list.SetHeight(max(0, height))
 }
 
 func (lp *ListPanel) SetItems(items []list.Item) {
+	lp.total = -1
+	lp.list.SetDelegate(PanelListItemDelegate{marked: lp.marked})
 	lp.list.SetItems(items)
-	lp.list.Title = fmt.Sprintf("%s (%d)", lp.title, len(items))
+	lp.refreshTitle()
+}
+
+// SetFilteredItems shows the items that matched a filter out of total,
+// highlighting the matched runes of each item's name
+func (lp *ListPanel) SetFilteredItems(items []list.Item, matches map[list.Item][]int, total int) {
+	lp.total = total
+	lp.list.SetDelegate(PanelListItemDelegate{marked: lp.marked, matches: matches})
+	lp.list.SetItems(items)
+	lp.refreshTitle()
+}
+
+// SetPrompt shows the filter input under the list, or hides it when empty
+func (lp *ListPanel) SetPrompt(prompt string) {
+	lp.prompt = prompt
+	lp.resize()
+}
+
+// SetNote sets the text shown after the item count in the title
+func (lp *ListPanel) SetNote(note string) {
+	lp.note = note
+	lp.refreshTitle()
+}
+
+func (lp *ListPanel) refreshTitle() {
+	lp.list.Title = fmt.Sprintf("%s (%d)", lp.title, len(lp.list.Items()))
+	if lp.total >= 0 {
+		lp.list.Title = fmt.Sprintf("%s (%d/%d)", lp.title, len(lp.list.Items()), lp.total)
+	}
+	if lp.note != "" {
+		lp.list.Title += " · " + lp.note
+	}
+}
+
+// SetMarker sets the function that decides which items show a mark
+func (lp *ListPanel) SetMarker(marked func(list.Item) bool) {
+	lp.marked = marked
+	lp.list.SetDelegate(PanelListItemDelegate{marked: marked})
 }
 
 func (lp ListPanel) SelectedItem() list.Item {
 	return lp.list.SelectedItem()
 }
 
+func (lp ListPanel) Items() []list.Item {
+	return lp.list.Items()
+}
+
 func (lp ListPanel) ItemCount() int {
 	return len(lp.list.Items())
 }
@@ -58,3 +125,14 @@ func (lp *ListPanel) SelectFirst() {
 		lp.list.Select(0)
 	}
 }
+
+// Select selects the first item matching fn and reports whether one was found
+func (lp *ListPanel) Select(fn func(list.Item) bool) bool {
+	for i, item := range lp.list.Items() {
+		if fn(item) {
+			lp.list.Select(i)
+			return true
+		}
+	}
+	return false
+}
@@ -3,12 +3,21 @@ package view
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"claudectl/internal/viewmodels"
 )
 
-type PanelListItemDelegate struct{}
+type PanelListItemDelegate struct {
+	// marked reports whether an item is marked for a bulk action
+	marked func(list.Item) bool
+	// matches are the rune indexes of each item's name that a filter matched
+	matches map[list.Item][]int
+}
 
 func (d PanelListItemDelegate) Height() int { return 1 }
 
@@ -18,6 +27,20 @@ func (d PanelListItemDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return
 
 func (d PanelListItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
 	title := listItem.FilterValue()
+	if matched := d.matches[listItem]; len(matched) > 0 {
+		base := unselectedItemStyle
+		if index == m.Index() {
+			base = selectedItemStyle
+		}
+		base = base.UnsetPadding().UnsetMargins()
+		title = lipgloss.StyleRunes(title, matched, filterMatchStyle.Inherit(base), base)
+	}
+	if d.marked != nil && d.marked(listItem) {
+		title = markedItemStyle.Render(SymbolMarked) + " " + title
+	}
+	if provider, ok := listItem.(viewmodels.ColumnProvider); ok {
+		title = withColumn(title, provider, m.Width())
+	}
 	if index == m.Index() {
 		icon := selectedItemIconStyle.Render(SymbolSelected + " ")
 		content := selectedItemStyle.Render(icon + title)
@@ -28,3 +51,28 @@ func (d PanelListItemDelegate) Render(w io.Writer, m list.Model, index int, list
 		fmt.Fprint(w, content)
 	}
 }
+
+// withColumn right-aligns the provider's status after title
+func withColumn(title string, provider viewmodels.ColumnProvider, width int) string {
+	text, level := provider.Column()
+	if text == "" {
+		return title
+	}
+
+	style := listColumnStyle
+	switch level {
+	case "ok":
+		style = statusSuccessStyle
+	case "warning":
+		style = statusWarningStyle
+	case "error":
+		style = statusErrorStyle
+	}
+
+	// Item padding, margins and the selection icon take six cells
+	gap := width - 6 - lipgloss.Width(title) - lipgloss.Width(text)
+	if gap < 1 {
+		gap = 1
+	}
+	return title + strings.Repeat(" ", gap) + style.Render(text)
+}
@@ -0,0 +1,145 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/truncate"
+
+	"claudectl/internal/search"
+)
+
+// searchResultLimit is how many results the search pane ranks
+const searchResultLimit = 50
+
+// searchJumpMsg asks the model to show a search result
+type searchJumpMsg struct {
+	result search.Result
+}
+
+// SearchPane searches everything loaded as the query is typed and jumps to
+// the chosen result
+type SearchPane struct {
+	index   *search.Index
+	input   textinput.Model
+	results []search.Result
+	cursor  int
+	offset  int
+	width   int
+	height  int
+}
+
+func NewSearchPane(index *search.Index, width, height int) *SearchPane {
+	input := textinput.New()
+	input.Prompt = SymbolPrompt + " "
+	input.Placeholder = "search names, descriptions and content"
+	input.CharLimit = 0
+	input.Width = width - 4
+	input.Focus()
+	return &SearchPane{index: index, input: input, width: width, height: height}
+}
+
+func (p *SearchPane) Update(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		return cancelModal
+	case "enter":
+		if len(p.results) == 0 {
+			return nil
+		}
+		result := p.results[p.cursor]
+		return func() tea.Msg { return searchJumpMsg{result: result} }
+	case "up", "ctrl+p":
+		p.move(-1)
+		return nil
+	case "down", "ctrl+n":
+		p.move(1)
+		return nil
+	}
+
+	query := p.input.Value()
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	if p.input.Value() != query {
+		p.results = p.index.Search(p.input.Value(), searchResultLimit)
+		p.cursor, p.offset = 0, 0
+	}
+	return cmd
+}
+
+// move moves the cursor and scrolls to keep it visible
+func (p *SearchPane) move(delta int) {
+	if len(p.results) == 0 {
+		return
+	}
+	p.cursor = max(0, min(len(p.results)-1, p.cursor+delta))
+	visible := p.visibleResults()
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if p.cursor >= p.offset+visible {
+		p.offset = p.cursor - visible + 1
+	}
+}
+
+// visibleResults is how many two-line results fit
+func (p *SearchPane) visibleResults() int {
+	return max(1, (p.height-6)/2)
+}
+
+func (p *SearchPane) View() string {
+	var b strings.Builder
+	switch {
+	case strings.TrimSpace(p.input.Value()) == "":
+		b.WriteString(emptyStateStyle.UnsetPadding().Render(fmt.Sprintf("Type to search %d items", p.index.Len())))
+	case len(p.results) == 0:
+		b.WriteString(emptyStateStyle.UnsetPadding().Render("Nothing matches"))
+	default:
+		end := min(len(p.results), p.offset+p.visibleResults())
+		for i := p.offset; i < end; i++ {
+			b.WriteString(p.renderResult(p.results[i], i == p.cursor))
+		}
+	}
+
+	hint := "↑/↓ select • enter jump • esc close"
+	if len(p.results) > 0 {
+		hint = fmt.Sprintf("%d/%d • %s", p.cursor+1, len(p.results), hint)
+	}
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		modalTitleStyle.Render("Search"),
+		p.input.View(),
+		"",
+		strings.TrimSuffix(b.String(), "\n"),
+		"",
+		modalHintStyle.Render(hint),
+	)
+	return modalStyle.Width(p.width).Render(body)
+}
+
+// renderResult renders a result's name and origin over its snippet
+func (p *SearchPane) renderResult(result search.Result, selected bool) string {
+	doc := result.Document
+	icon, nameStyle := "  ", detailValueStyle
+	if selected {
+		icon, nameStyle = selectedItemIconStyle.Render(SymbolArrow)+" ", detailNameStyle
+	}
+	origin := doc.Kind + " • " + string(doc.Scope)
+	if doc.Plugin != "" {
+		origin += " • plugin " + doc.Plugin
+	}
+
+	snippet := result.Snippet.Highlight(func(s string) string { return searchMatchStyle.Render(s) })
+	if location := result.Location(); location != "" {
+		snippet = detailFilepathStyle.Render(location) + "  " + snippet
+	}
+	width := p.width - 6
+
+	var b strings.Builder
+	b.WriteString(icon + nameStyle.Render(doc.Name) + "  " + listColumnStyle.Render(origin))
+	b.WriteString("\n")
+	b.WriteString(truncate.StringWithTail("  "+snippet, uint(max(0, width)), "…"))
+	b.WriteString("\n")
+	return b.String()
+}
@@ -16,6 +16,8 @@ const (
 	BorderSpacing     = 4
 	TabBarHeight      = 6
 	PanelSeparatorGap = 2
+
+	modalInputWidth = 48
 )
 
 // Minimal symbol set - Clean and functional
@@ -24,6 +26,7 @@ const (
 	SymbolSelected   = "●"
 	SymbolUnselected = "○"
 	SymbolArrow      = "▶"
+	SymbolMarked     = "◆"
 
 	// Status
 	SymbolCheck      = "✓"
@@ -174,6 +177,12 @@ var (
 		Bold(true).
 		Padding(0, 1)
 
+	localScopeBadgeStyle = lipgloss.NewStyle().
+		Foreground(fgColor).
+		Background(bgHighlight).
+		Bold(true).
+		Padding(0, 1)
+
 	// Divider style - Simple
 	dividerStyle = lipgloss.NewStyle().
 		Foreground(borderColor)
@@ -187,6 +196,43 @@ var (
 		Foreground(warningColor).
 		Bold(true)
 
+	markedItemStyle = lipgloss.NewStyle().
+		Foreground(warningColor)
+
+	listColumnStyle = lipgloss.NewStyle().
+		Foreground(textMuted)
+
+	// Trace pane event headers
+	traceSentStyle = lipgloss.NewStyle().
+		Foreground(primaryBright).
+		Bold(true)
+
+	traceRecvStyle = lipgloss.NewStyle().
+		Foreground(successColor).
+		Bold(true)
+
+	traceInfoStyle = lipgloss.NewStyle().
+		Foreground(textMuted)
+
+	// Argument preview substitutions
+	argumentStyle = lipgloss.NewStyle().
+		Foreground(successColor).
+		Underline(true)
+
+	missingArgumentStyle = lipgloss.NewStyle().
+		Foreground(errorColor).
+		Strikethrough(true)
+
+	// Name runes that match a list filter
+	filterMatchStyle = lipgloss.NewStyle().
+		Foreground(primaryBright).
+		Underline(true)
+
+	// Words that match a search
+	searchMatchStyle = lipgloss.NewStyle().
+		Foreground(warningColor).
+		Bold(true)
+
 	statusErrorStyle = lipgloss.NewStyle().
 		Foreground(errorColor).
 		Bold(true)
@@ -199,6 +245,32 @@ var (
 	terminalPromptStyle = lipgloss.NewStyle().
 		Foreground(primaryBright).
 		Bold(true)
+
+	// Modal styles
+	modalStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderActive).
+		Padding(1, 2)
+
+	modalTitleStyle = lipgloss.NewStyle().
+		Foreground(primaryBright).
+		Bold(true)
+
+	modalHintStyle = lipgloss.NewStyle().
+		Foreground(textDim)
+
+	formLabelStyle = lipgloss.NewStyle().
+		Foreground(textMuted).
+		Width(12)
+
+	formFocusedLabelStyle = lipgloss.NewStyle().
+		Foreground(primaryBright).
+		Bold(true).
+		Width(12)
+
+	// Status line shown above the help bar
+	statusLineStyle = lipgloss.NewStyle().
+		Padding(0, 1)
 )
 
 // RenderTabs renders the tab bar with clean block design
@@ -237,6 +309,8 @@ func RenderScopeBadge(scope string) string {
 		return userScopeBadgeStyle.Render(" USER ")
 	case "project", "Project":
 		return projectScopeBadgeStyle.Render(" PROJECT ")
+	case "local", "Local":
+		return localScopeBadgeStyle.Render(" LOCAL ")
 	default:
 		return statusInfoStyle.Render(" " + scope + " ")
 	}
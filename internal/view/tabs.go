@@ -5,32 +5,41 @@ import "claudectl/internal/domain"
 type TabType int
 
 const (
-	MCPsTab TabType = iota
+	OverviewTab TabType = iota
+	MCPsTab
 	CommandsTab
 	SkillsTab
 	PluginsTab
 	AgentsTab
 )
 
+// tab describes a tab; capType is empty for tabs that do not list
+// capabilities
+type tab struct {
+	name    string
+	capType domain.CapabilityType
+}
+
+// tabs are the tabs in TabType order. Each gets the number key of its
+// position.
+var tabs = [...]tab{
+	OverviewTab: {"Overview", ""},
+	MCPsTab:     {"MCPs", domain.TypeMCP},
+	CommandsTab: {"Commands", domain.TypeCommand},
+	SkillsTab:   {"Skills", domain.TypeSkill},
+	PluginsTab:  {"Plugins", domain.TypePlugin},
+	AgentsTab:   {"Agents", domain.TypeAgent},
+}
+
+const TabCount = len(tabs)
+
 func (t TabType) String() string {
-	switch t {
-	case MCPsTab:
-		return "MCPs"
-	case CommandsTab:
-		return "Commands"
-	case SkillsTab:
-		return "Skills"
-	case PluginsTab:
-		return "Plugins"
-	case AgentsTab:
-		return "Agents"
-	default:
+	if t < 0 || int(t) >= TabCount {
 		return "Unknown"
 	}
+	return tabs[t].name
 }
 
-const TabCount = 5
-
 func (t TabType) NextTab() TabType {
 	return TabType((int(t) + 1) % TabCount)
 }
@@ -39,19 +48,20 @@ func (t TabType) PrevTab() TabType {
 	return TabType((int(t) - 1 + TabCount) % TabCount)
 }
 
+// ToCapabilityType returns the type a tab lists, or "" for the overview
 func (t TabType) ToCapabilityType() domain.CapabilityType {
-	switch t {
-	case MCPsTab:
-		return domain.TypeMCP
-	case CommandsTab:
-		return domain.TypeCommand
-	case SkillsTab:
-		return domain.TypeSkill
-	case AgentsTab:
-		return domain.TypeAgent
-	case PluginsTab:
-		return domain.TypePlugin
-	default:
-		return domain.TypeMCP
+	if t < 0 || int(t) >= TabCount {
+		return ""
+	}
+	return tabs[t].capType
+}
+
+// tabForType returns the tab that lists capType
+func tabForType(capType domain.CapabilityType) (TabType, bool) {
+	for i, tab := range tabs {
+		if tab.capType == capType && capType != "" {
+			return TabType(i), true
+		}
 	}
+	return OverviewTab, false
 }
@@ -0,0 +1,106 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"claudectl/internal/mcpclient"
+)
+
+type traceRerunMsg struct {
+	pane *TracePane
+}
+
+// TracePane is a scrollable view of a server's trace buffer
+type TracePane struct {
+	title    string
+	trace    *mcpclient.Trace
+	viewport viewport.Model
+	status   string
+	failed   bool
+	context  any
+}
+
+func NewTracePane(title string, trace *mcpclient.Trace, width, height int, context any) *TracePane {
+	vp := viewport.New(width, height)
+	p := &TracePane{title: title, trace: trace, viewport: vp, context: context}
+	p.Refresh()
+	return p
+}
+
+func (p *TracePane) SetStatus(status string, failed bool) {
+	p.status = status
+	p.failed = failed
+}
+
+// Refresh re-reads the trace, staying at the bottom if already there
+func (p *TracePane) Refresh() {
+	atBottom := p.viewport.AtBottom()
+
+	var b strings.Builder
+	events := p.trace.Events()
+	if len(events) == 0 {
+		b.WriteString(emptyStateStyle.Render("No traffic recorded yet"))
+	}
+	for _, event := range events {
+		style := traceInfoStyle
+		switch event.Kind {
+		case mcpclient.EventSent:
+			style = traceSentStyle
+		case mcpclient.EventRecv:
+			style = traceRecvStyle
+		case mcpclient.EventStderr:
+			style = statusWarningStyle
+		case mcpclient.EventError:
+			style = statusErrorStyle
+		}
+		// The first line carries the event kind, the rest is the message
+		header, body, multiline := strings.Cut(event.Format(), "\n")
+		b.WriteString(style.Render(header) + "\n")
+		if multiline {
+			b.WriteString(detailValueStyle.Render(body) + "\n")
+		}
+	}
+
+	p.viewport.SetContent(b.String())
+	if atBottom {
+		p.viewport.GotoBottom()
+	}
+}
+
+func (p *TracePane) Update(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "q":
+		return cancelModal
+	case "r":
+		return func() tea.Msg { return traceRerunMsg{pane: p} }
+	case "g", "home":
+		p.viewport.GotoTop()
+		return nil
+	case "G", "end":
+		p.viewport.GotoBottom()
+		return nil
+	}
+	var cmd tea.Cmd
+	p.viewport, cmd = p.viewport.Update(msg)
+	return cmd
+}
+
+func (p *TracePane) View() string {
+	status := modalHintStyle.Render(p.status)
+	if p.failed {
+		status = statusErrorStyle.Render(SymbolCross + " " + p.status)
+	}
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		modalTitleStyle.Render(p.title)+"  "+status,
+		"",
+		p.viewport.View(),
+		"",
+		modalHintStyle.Render(fmt.Sprintf("↑/↓ scroll • g/G top/bottom • r run again • esc close • %d%%", int(p.viewport.ScrollPercent()*100))),
+	)
+	return modalStyle.Render(body)
+}
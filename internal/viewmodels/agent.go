@@ -2,6 +2,7 @@ package viewmodels
 
 import (
 	"fmt"
+	"strings"
 
 	"claudectl/internal/domain"
 )
@@ -13,17 +14,44 @@ type AgentViewModel struct {
 	capType     domain.CapabilityType
 	filePath    string
 	content     string
+	tools       []string
+	// toolProblems are the tools entries that do not resolve
+	toolProblems []string
 }
 
 func NewAgentViewModel(agent *domain.Agent) *AgentViewModel {
-	return &AgentViewModel{
+	vm := &AgentViewModel{
 		name:        agent.Name,
 		description: agent.Description,
 		scope:       agent.Scope,
 		capType:     agent.Type,
 		filePath:    agent.FilePath,
 		content:     agent.Content,
+		tools:       agent.Tools,
 	}
+	vm.ResolveTools(nil)
+	return vm
+}
+
+// ResolveTools checks the tools list against the built-in tools and the
+// configured MCP servers. A nil servers skips the MCP server check.
+func (vm *AgentViewModel) ResolveTools(servers []string) {
+	vm.toolProblems = nil
+	for _, entry := range vm.tools {
+		rule, ok := domain.ParseToolRule(entry)
+		if !ok {
+			vm.toolProblems = append(vm.toolProblems, fmt.Sprintf("malformed tools entry %q", entry))
+			continue
+		}
+		if err := domain.CheckTool(rule.Tool, servers); err != nil {
+			vm.toolProblems = append(vm.toolProblems, err.Error())
+		}
+	}
+}
+
+// InheritsTools reports whether the agent can use every tool
+func (vm *AgentViewModel) InheritsTools() bool {
+	return len(vm.tools) == 0
 }
 
 
@@ -41,8 +69,26 @@ func (vm *AgentViewModel) Description() string {
 
 
 func (vm *AgentViewModel) RenderDetails() []string {
-	// No type-specific details to display
-	return []string{}
+	if vm.InheritsTools() {
+		return []string{"Tools: all (inherited, no tools restriction)"}
+	}
+	return []string{fmt.Sprintf("Tools: %s", strings.Join(vm.tools, ", "))}
+}
+
+// Warnings lists tools entries that do not resolve
+func (vm *AgentViewModel) Warnings() []string {
+	return vm.toolProblems
+}
+
+// Column marks agents with every tool and agents with unknown tools
+func (vm *AgentViewModel) Column() (string, string) {
+	switch {
+	case len(vm.toolProblems) > 0:
+		return "unknown tools", "warning"
+	case vm.InheritsTools():
+		return "all tools", "warning"
+	}
+	return fmt.Sprintf("%d tools", len(vm.tools)), ""
 }
 
 
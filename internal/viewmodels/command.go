@@ -2,37 +2,52 @@ package viewmodels
 
 import (
 	"fmt"
+	"strings"
 
 	"claudectl/internal/domain"
 )
 
 type CommandViewModel struct {
-	name        string
-	description string
-	scope       domain.CapabilityScope
-	capType     domain.CapabilityType
-	filePath    string
-	content     string
+	name           string
+	description    string
+	scope          domain.CapabilityScope
+	capType        domain.CapabilityType
+	namespace      string
+	filePath       string
+	content        string
+	argumentHint   string
+	allowedTools   []string
+	fileReferences []domain.FileReference
+	shellSnippets  []domain.ShellSnippet
 }
 
 func NewCommandViewModel(cmd *domain.Command) *CommandViewModel {
 	return &CommandViewModel{
-		name:        cmd.Name,
-		description: cmd.Description,
-		scope:       cmd.Scope,
-		capType:     cmd.Type,
-		filePath:    cmd.FilePath,
-		content:     cmd.Content,
+		name:           cmd.Name,
+		description:    cmd.Description,
+		scope:          cmd.Scope,
+		capType:        cmd.Type,
+		namespace:      cmd.Namespace,
+		filePath:       cmd.FilePath,
+		content:        cmd.Content,
+		argumentHint:   cmd.ArgumentHint,
+		allowedTools:   cmd.AllowedTools,
+		fileReferences: cmd.FileReferences,
+		shellSnippets:  cmd.ShellSnippets,
 	}
 }
 
 
 func (vm *CommandViewModel) FilterValue() string {
-	return vm.name
+	return vm.Title()
 }
 
+// Title shows namespaced commands as namespace:name
 func (vm *CommandViewModel) Title() string {
-	return vm.name
+	if vm.namespace == "" {
+		return vm.name
+	}
+	return vm.namespace + ":" + vm.name
 }
 
 func (vm *CommandViewModel) Description() string {
@@ -41,8 +56,45 @@ func (vm *CommandViewModel) Description() string {
 
 
 func (vm *CommandViewModel) RenderDetails() []string {
-	// Metadata removed - return empty slice
-	return []string{}
+	details := []string{}
+	if vm.namespace != "" {
+		details = append(details, fmt.Sprintf("Namespace: %s", vm.namespace))
+	}
+	if vm.argumentHint != "" {
+		details = append(details, fmt.Sprintf("Arguments: %s", vm.argumentHint))
+	}
+	if len(vm.allowedTools) > 0 {
+		details = append(details, fmt.Sprintf("Allowed tools: %s", strings.Join(vm.allowedTools, ", ")))
+	}
+	for _, ref := range vm.fileReferences {
+		details = append(details, fmt.Sprintf("Reads: @%s (line %d)", ref.Path, ref.Line))
+	}
+	for _, snippet := range vm.shellSnippets {
+		details = append(details, fmt.Sprintf("Runs: %s (line %d)", snippet.Command, snippet.Line))
+	}
+	return details
+}
+
+// Warnings flags referenced files that do not exist and shell snippets
+// that allowed-tools does not permit
+func (vm *CommandViewModel) Warnings() []string {
+	var warnings []string
+	for _, ref := range vm.fileReferences {
+		if ref.Missing {
+			warnings = append(warnings, fmt.Sprintf("@%s does not exist (line %d)", ref.Path, ref.Line))
+		}
+	}
+	for _, snippet := range vm.shellSnippets {
+		if !domain.AllowsBash(vm.allowedTools, snippet.Command) {
+			warnings = append(warnings, fmt.Sprintf("%q is not permitted by allowed-tools (line %d)", snippet.Command, snippet.Line))
+		}
+	}
+	return warnings
+}
+
+// ArgumentHint is the command's argument-hint, if it has one
+func (vm *CommandViewModel) ArgumentHint() string {
+	return vm.argumentHint
 }
 
 
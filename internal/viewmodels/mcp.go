@@ -1,9 +1,12 @@
 package viewmodels
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 
 	"claudectl/internal/domain"
+	"claudectl/internal/mcpaudit"
 )
 
 type MCPServerViewModel struct {
@@ -12,26 +15,44 @@ type MCPServerViewModel struct {
 	description string
 	scope       domain.CapabilityScope
 	capType     domain.CapabilityType
+	filePath    string
+	server      *domain.MCPServer
 
 	// MCP-specific fields
-	command string
-	args    []string
-	env     map[string]string
-	mcpType string
-	url     string
+	command       string
+	args          []string
+	env           map[string]string
+	mcpType       string
+	url           string
+	headers       map[string]string
+	headersHelper string
+	timeout       int
+	extra         map[string]json.RawMessage
+	transport     domain.MCPTransport
+	problems      []string
+	audit         mcpaudit.Report
 }
 
 func NewMCPServerViewModel(server *domain.MCPServer) *MCPServerViewModel {
 	return &MCPServerViewModel{
-		name:        server.Name,
-		description: server.Description,
-		scope:       server.Scope,
-		capType:     server.Type,
-		command:     server.Command,
-		args:        server.Args,
-		env:         server.Env,
-		mcpType:     server.MCPType,
-		url:         server.Url,
+		name:          server.Name,
+		description:   server.Description,
+		scope:         server.Scope,
+		capType:       server.Type,
+		filePath:      server.FilePath,
+		server:        server,
+		command:       server.Command,
+		args:          server.Args,
+		env:           server.Env,
+		mcpType:       server.MCPType,
+		url:           server.Url,
+		headers:       server.Headers,
+		headersHelper: server.HeadersHelper,
+		timeout:       server.Timeout,
+		extra:         server.Extra,
+		transport:     server.Transport(),
+		problems:      server.ConfigProblems(),
+		audit:         mcpaudit.Audit(*server),
 	}
 }
 
@@ -50,9 +71,13 @@ func (vm *MCPServerViewModel) Description() string {
 func (vm *MCPServerViewModel) RenderDetails() []string {
 	details := []string{}
 
-	if vm.mcpType != "" {
-		details = append(details, fmt.Sprintf("Type: %s", vm.mcpType))
+	transport := string(vm.transport)
+	if vm.mcpType == "" {
+		transport += " (default, no type set)"
+	} else if vm.transport == domain.TransportUnknown {
+		transport = vm.mcpType + " (unknown)"
 	}
+	details = append(details, fmt.Sprintf("Transport: %s", transport))
 
 	if vm.url != "" {
 		details = append(details, fmt.Sprintf("URL: %s", vm.url))
@@ -62,23 +87,85 @@ func (vm *MCPServerViewModel) RenderDetails() []string {
 		details = append(details, fmt.Sprintf("Command: %s", vm.command))
 	}
 
+	if vm.audit.ResolvedCommand != "" && vm.audit.ResolvedCommand != vm.command {
+		details = append(details, fmt.Sprintf("Resolves to: %s", vm.audit.ResolvedCommand))
+	}
+
+	if pkg := vm.audit.Package; pkg != nil {
+		pinning := "unpinned"
+		if pkg.Pinned {
+			pinning = "pinned"
+		}
+		details = append(details, fmt.Sprintf("Package: %s (%s, %s)", pkg, pkg.Launcher, pinning))
+	}
+
 	if len(vm.args) > 0 {
 		details = append(details, "Arguments:")
 		for _, arg := range vm.args {
-			details = append(details, fmt.Sprintf(" %s", arg))
+			details = append(details, fmt.Sprintf("  %s", arg))
 		}
 	}
 
 	if len(vm.env) > 0 {
-		details = append(details, "Environment:\n")
-		for k, v := range vm.env {
-			details = append(details, fmt.Sprintf("  %s=%s\n", k, v))
+		details = append(details, "Environment:")
+		for _, k := range sortedKeys(vm.env) {
+			details = append(details, fmt.Sprintf("  %s=%s", k, vm.env[k]))
+		}
+	}
+
+	if len(vm.headers) > 0 {
+		details = append(details, "Headers:")
+		for _, k := range sortedKeys(vm.headers) {
+			details = append(details, fmt.Sprintf("  %s: %s", k, vm.headers[k]))
+		}
+	}
+
+	if vm.headersHelper != "" {
+		details = append(details, fmt.Sprintf("Headers helper: %s", vm.headersHelper))
+	}
+
+	if vm.timeout > 0 {
+		details = append(details, fmt.Sprintf("Timeout: %dms", vm.timeout))
+	}
+
+	if len(vm.extra) > 0 {
+		details = append(details, "Other fields:")
+		keys := make([]string, 0, len(vm.extra))
+		for k := range vm.extra {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			details = append(details, fmt.Sprintf("  %s: %s", k, vm.extra[k]))
 		}
 	}
 
 	return details
 }
 
+// Warnings returns the config problems and launch audit findings
+func (vm *MCPServerViewModel) Warnings() []string {
+	warnings := append([]string{}, vm.problems...)
+	for _, finding := range vm.audit.Findings {
+		if finding.Severity != mcpaudit.SeverityInfo {
+			warnings = append(warnings, finding.Message)
+		}
+	}
+	return warnings
+}
+
+// Column shows the launch audit status in the list panel
+func (vm *MCPServerViewModel) Column() (string, string) {
+	status := vm.audit.Status()
+	if len(vm.problems) > 0 {
+		return "invalid", string(mcpaudit.SeverityError)
+	}
+	if status == mcpaudit.StatusRemote {
+		return string(vm.transport), ""
+	}
+	return vm.audit.Summary(), status
+}
+
 func (vm *MCPServerViewModel) GetName() string {
 	return vm.name
 }
@@ -95,12 +182,26 @@ func (vm *MCPServerViewModel) GetType() domain.CapabilityType {
 	return vm.capType
 }
 
-// GetFilePath returns the file path (MCP servers don't have a file path, return empty)
+// GetFilePath returns the config file the server is defined in
 func (vm *MCPServerViewModel) GetFilePath() string {
-	return ""
+	return vm.filePath
 }
 
 // GetContent returns the markdown content (MCP servers don't have content, return empty)
 func (vm *MCPServerViewModel) GetContent() string {
 	return ""
 }
+
+// Server returns the domain model the view model was built from
+func (vm *MCPServerViewModel) Server() *domain.MCPServer {
+	return vm.server
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -15,13 +15,16 @@ type PluginViewModel struct {
 	path        string
 
 	// Plugin-specific fields
-	version    string
-	authorName string
-	license    string
+	version       string
+	authorName    string
+	license       string
+	key           string
+	latestVersion string
+	plugin        *domain.Plugin
 }
 
 func NewPluginViewModel(plugin *domain.Plugin) *PluginViewModel {
-	return &PluginViewModel{
+	vm := &PluginViewModel{
 		name:        plugin.Name,
 		description: plugin.Description,
 		scope:       plugin.Scope,
@@ -30,7 +33,13 @@ func NewPluginViewModel(plugin *domain.Plugin) *PluginViewModel {
 		version:     plugin.Version,
 		authorName:  plugin.Author.Name,
 		license:     plugin.License,
+		key:         plugin.Key,
+		plugin:      plugin,
 	}
+	if plugin.HasUpdate() {
+		vm.latestVersion = plugin.LatestVersion
+	}
+	return vm
 }
 
 func (vm *PluginViewModel) FilterValue() string {
@@ -58,9 +67,56 @@ func (vm *PluginViewModel) RenderDetails() []string {
 		details = append(details, fmt.Sprintf("License: %s", vm.license))
 	}
 
+	if vm.latestVersion != "" {
+		details = append(details, fmt.Sprintf("Update available: %s", vm.latestVersion))
+	}
+
 	return details
 }
 
+// Plugin returns the plugin, including what it ships
+func (vm *PluginViewModel) Plugin() *domain.Plugin {
+	return vm.plugin
+}
+
+// Contents returns view models of the commands, skills, agents and MCP
+// servers the plugin ships
+func (vm *PluginViewModel) Contents() []CapabilityViewModel {
+	if vm.plugin == nil {
+		return nil
+	}
+	var contents []CapabilityViewModel
+	for i := range vm.plugin.MCPServers {
+		contents = append(contents, NewMCPServerViewModel(&vm.plugin.MCPServers[i]))
+	}
+	for i := range vm.plugin.Commands {
+		contents = append(contents, NewCommandViewModel(&vm.plugin.Commands[i]))
+	}
+	for i := range vm.plugin.Skills {
+		contents = append(contents, NewSkillViewModel(&vm.plugin.Skills[i]))
+	}
+	for i := range vm.plugin.Agents {
+		contents = append(contents, NewAgentViewModel(&vm.plugin.Agents[i]))
+	}
+	return contents
+}
+
+// Key is the registry key, name@marketplace
+func (vm *PluginViewModel) Key() string {
+	return vm.key
+}
+
+// Version is the installed version
+func (vm *PluginViewModel) Version() string {
+	return vm.version
+}
+
+// UpdateVersion is the version the marketplace offers when it differs from
+// the installed one, or ""
+func (vm *PluginViewModel) UpdateVersion() string {
+	return vm.latestVersion
+}
+
 func (vm *PluginViewModel) GetName() string {
 	return vm.name
 }
@@ -23,6 +23,19 @@ type CapabilityViewModel interface {
 	RenderDetails() []string
 }
 
+// WarningProvider is implemented by view models that can flag problems
+// with the underlying capability
+type WarningProvider interface {
+	Warnings() []string
+}
+
+// ColumnProvider is implemented by view models that show a short status
+// next to their name in list panels. Level is "ok", "warning", "error" or
+// empty for neutral information.
+type ColumnProvider interface {
+	Column() (text, level string)
+}
+
 // ToDomainViewModel converts a domain model to a view model
 // Note: Only handles value types since loaders return slices of values
 func ToDomainViewModel(cap any) (CapabilityViewModel, error) {
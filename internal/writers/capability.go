@@ -0,0 +1,245 @@
+package writers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/loaders"
+	"claudectl/internal/scaffold"
+	"claudectl/internal/trash"
+	"claudectl/internal/utils"
+	"claudectl/internal/viewmodels"
+)
+
+// CapabilityWriter deletes and renames commands, skills, agents and MCP
+// servers. Deleted items go to the trash so they can be restored.
+type CapabilityWriter interface {
+	Remove(vm viewmodels.CapabilityViewModel) (*trash.Entry, error)
+	// Rename gives an item a new name in the same scope and returns the
+	// name to select it by. Commands may also change namespace.
+	Rename(vm viewmodels.CapabilityViewModel, newName string) (string, error)
+	Restore(idOrName string) (*trash.Entry, error)
+	Trash() (*trash.Trash, error)
+}
+
+type capabilityWriterImpl struct {
+	logger    *slog.Logger
+	mcpWriter MCPWriter
+}
+
+func NewCapabilityWriter(logger *utils.Logger, mcpWriter MCPWriter) CapabilityWriter {
+	logger.Debug("initializing capability writer")
+	return &capabilityWriterImpl{logger: logger.Logger, mcpWriter: mcpWriter}
+}
+
+func (w *capabilityWriterImpl) Trash() (*trash.Trash, error) {
+	return trash.Open()
+}
+
+func (w *capabilityWriterImpl) Remove(vm viewmodels.CapabilityViewModel) (*trash.Entry, error) {
+	bin, err := trash.Open()
+	if err != nil {
+		return nil, err
+	}
+	entry := trash.Entry{Type: vm.GetType(), Name: vm.FilterValue(), Scope: vm.GetScope()}
+
+	switch v := vm.(type) {
+	case *viewmodels.MCPServerViewModel:
+		server := v.Server()
+		config, err := json.Marshal(loaders.NewMCPServerConfig(server))
+		if err != nil {
+			return nil, err
+		}
+		entry.Path = server.FilePath
+		if server.Scope == domain.ScopeLocal {
+			if entry.Project, err = utils.GetProjectRoot(); err != nil {
+				return nil, err
+			}
+		}
+		added, err := bin.AddServer(entry, config)
+		if err != nil {
+			return nil, err
+		}
+		if err := w.mcpWriter.Remove(*server); err != nil {
+			bin.Discard(added.ID)
+			return nil, err
+		}
+		return added, nil
+
+	case *viewmodels.CommandViewModel, *viewmodels.AgentViewModel:
+		return bin.AddPath(entry, vm.GetFilePath())
+
+	case *viewmodels.SkillViewModel:
+		// A skill is its whole directory, including scripts and references
+		return bin.AddPath(entry, filepath.Dir(vm.GetFilePath()))
+
+	default:
+		return nil, fmt.Errorf("cannot delete a %s", vm.GetType())
+	}
+}
+
+func (w *capabilityWriterImpl) Rename(vm viewmodels.CapabilityViewModel, newName string) (string, error) {
+	// Server names are config keys with their own rules and no namespaces
+	if v, ok := vm.(*viewmodels.MCPServerViewModel); ok {
+		if err := domain.ValidateMCPServerName(newName); err != nil {
+			return "", err
+		}
+		return newName, w.mcpWriter.Rename(*v.Server(), newName)
+	}
+
+	kind := vm.GetType()
+	namespace, name := scaffold.SplitName(newName)
+	if err := scaffold.ValidateName(kind, namespace, name); err != nil {
+		return "", err
+	}
+
+	switch vm.(type) {
+	case *viewmodels.CommandViewModel:
+		target, err := scaffold.Path(kind, vm.GetScope(), namespace, name)
+		if err != nil {
+			return "", err
+		}
+		if err := renamePath(vm.GetFilePath(), target); err != nil {
+			return "", err
+		}
+		if namespace != "" {
+			return namespace + ":" + name, nil
+		}
+		return name, nil
+
+	case *viewmodels.AgentViewModel:
+		path := vm.GetFilePath()
+		target := filepath.Join(filepath.Dir(path), name+".md")
+		if err := renamePath(path, target); err != nil {
+			return "", err
+		}
+		return name, w.renameFrontmatter(path, target, target, name)
+
+	case *viewmodels.SkillViewModel:
+		path := vm.GetFilePath()
+		dir := filepath.Dir(path)
+		target := filepath.Join(filepath.Dir(dir), name)
+		if err := renamePath(dir, target); err != nil {
+			return "", err
+		}
+		return name, w.renameFrontmatter(dir, target, filepath.Join(target, filepath.Base(path)), name)
+
+	default:
+		return "", fmt.Errorf("cannot rename a %s", kind)
+	}
+}
+
+func (w *capabilityWriterImpl) Restore(idOrName string) (*trash.Entry, error) {
+	bin, err := trash.Open()
+	if err != nil {
+		return nil, err
+	}
+	entry, err := bin.Find(idOrName)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.Server == nil {
+		return entry, bin.RestorePath(entry)
+	}
+
+	if err := checkRestoreLocation(entry); err != nil {
+		return nil, err
+	}
+	var config loaders.MCPServerConfig
+	if err := json.Unmarshal(entry.Server, &config); err != nil {
+		return nil, err
+	}
+	if err := w.mcpWriter.Add(*config.ToDomain(entry.Name, entry.Scope)); err != nil {
+		return nil, err
+	}
+	return entry, bin.Discard(entry.ID)
+}
+
+// checkRestoreLocation refuses to restore an MCP server anywhere but the
+// config file, and for local servers the project, it was deleted from
+func checkRestoreLocation(entry *trash.Entry) error {
+	configPath, _, err := loaders.MCPConfigLocation(entry.Scope)
+	if err != nil {
+		return err
+	}
+	if entry.Path != "" && filepath.Clean(entry.Path) != filepath.Clean(configPath) {
+		return fmt.Errorf("%s was deleted from %s; restore it from that project", entry.Name, entry.Path)
+	}
+	if entry.Project == "" {
+		return nil
+	}
+	root, err := utils.GetProjectRoot()
+	if err != nil {
+		return err
+	}
+	if filepath.Clean(root) != filepath.Clean(entry.Project) {
+		return fmt.Errorf("%s was deleted from project %s; restore it from there", entry.Name, entry.Project)
+	}
+	return nil
+}
+
+// renameFrontmatter updates the name: field of a renamed item's file,
+// moving the item back if that fails so the rename is all or nothing
+func (w *capabilityWriterImpl) renameFrontmatter(from, to, file, name string) error {
+	err := setFrontmatterName(file, name)
+	if err == nil {
+		return nil
+	}
+	if rollbackErr := os.Rename(to, from); rollbackErr != nil {
+		w.logger.Error("failed to roll back rename", "from", to, "to", from, "error", rollbackErr)
+	}
+	return err
+}
+
+// renamePath moves an item within its scope, refusing to replace another
+func renamePath(from, to string) error {
+	if err := checkFree(from, to); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+		return err
+	}
+	return os.Rename(from, to)
+}
+
+func checkFree(from, to string) error {
+	if from == to {
+		return fmt.Errorf("%s already has that name", from)
+	}
+	if _, err := os.Lstat(to); err == nil {
+		return fmt.Errorf("%s already exists", to)
+	}
+	return nil
+}
+
+var (
+	frontmatterPattern = regexp.MustCompile(`(?s)\A---\r?\n.*?\r?\n---\r?\n`)
+	nameLinePattern    = regexp.MustCompile(`(?m)^name:[^\r\n]*`)
+)
+
+// setFrontmatterName updates the name: field of a markdown file, leaving
+// files without one alone
+func setFrontmatterName(path, name string) error {
+	snapshot, err := utils.ReadFileSnapshot(path)
+	if err != nil {
+		return err
+	}
+	loc := frontmatterPattern.FindIndex(snapshot.Data)
+	if loc == nil {
+		return nil
+	}
+	frontmatter := snapshot.Data[loc[0]:loc[1]]
+	if !nameLinePattern.Match(frontmatter) {
+		return nil
+	}
+
+	updated := nameLinePattern.ReplaceAll(frontmatter, []byte("name: "+name))
+	data := append(append(append([]byte{}, snapshot.Data[:loc[0]]...), updated...), snapshot.Data[loc[1]:]...)
+	return utils.WriteFileAtomic(snapshot, data)
+}
@@ -0,0 +1,337 @@
+package writers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"claudectl/internal/domain"
+	"claudectl/internal/jsonedit"
+	"claudectl/internal/loaders"
+	"claudectl/internal/utils"
+)
+
+var (
+	ErrServerExists   = errors.New("MCP server already exists")
+	ErrServerNotFound = errors.New("MCP server not found")
+	ErrServerChanged  = errors.New("MCP server was modified since it was loaded")
+)
+
+// MCPWriter changes MCP server entries in Claude Code's config files. Only
+// the affected entry is touched; every other key keeps its value and order.
+type MCPWriter interface {
+	Add(server domain.MCPServer) error
+	Put(overwrite bool, servers ...domain.MCPServer) error
+	Update(original, updated domain.MCPServer) error
+	Remove(server domain.MCPServer) error
+	Copy(server domain.MCPServer, target domain.MCPServer, overwrite bool) error
+	Move(server domain.MCPServer, target domain.MCPServer, overwrite bool) error
+	Rename(server domain.MCPServer, name string) error
+}
+
+type mcpWriterImpl struct {
+	logger *slog.Logger
+}
+
+func NewMCPWriter(logger *utils.Logger) MCPWriter {
+	logger.Debug("initializing MCP writer")
+	return &mcpWriterImpl{logger: logger.Logger}
+}
+
+func (w *mcpWriterImpl) Add(server domain.MCPServer) error {
+	return w.Put(false, server)
+}
+
+// Put writes several servers of the same scope in a single file update.
+// Existing entries are only replaced when overwrite is set.
+func (w *mcpWriterImpl) Put(overwrite bool, servers ...domain.MCPServer) error {
+	if len(servers) == 0 {
+		return nil
+	}
+	scope := servers[0].Scope
+	for _, server := range servers {
+		if server.Scope != scope {
+			return errors.New("all servers must share one scope")
+		}
+	}
+
+	return w.edit(scope, func(data []byte, serversPath []string) ([]byte, error) {
+		for _, server := range servers {
+			entryPath := childPath(serversPath, server.Name)
+			if _, err := jsonedit.Find(data, entryPath...); err == nil && !overwrite {
+				return nil, fmt.Errorf("%w: %s (%s)", ErrServerExists, server.Name, server.Scope)
+			}
+			var err error
+			if data, err = jsonedit.Set(data, loaders.NewMCPServerConfig(&server), entryPath...); err != nil {
+				return nil, err
+			}
+		}
+		return data, nil
+	})
+}
+
+// Update rewrites the fields of an entry that differ between original and
+// updated. It fails if the entry on disk no longer matches original.
+func (w *mcpWriterImpl) Update(original, updated domain.MCPServer) error {
+	if original.Name != updated.Name || original.Scope != updated.Scope {
+		return errors.New("update cannot rename a server or change its scope")
+	}
+
+	return w.edit(original.Scope, func(data []byte, serversPath []string) ([]byte, error) {
+		entryPath := childPath(serversPath, original.Name)
+		current, err := w.currentEntry(data, entryPath, original)
+		if err != nil {
+			return nil, err
+		}
+
+		next, err := configFields(loaders.NewMCPServerConfig(&updated))
+		if err != nil {
+			return nil, err
+		}
+
+		// Apply field by field so keys keep their position in the entry
+		keys, err := jsonedit.Keys(data, entryPath...)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			if _, ok := next[key]; !ok {
+				if data, err = jsonedit.Delete(data, childPath(entryPath, key)...); err != nil {
+					return nil, err
+				}
+			}
+		}
+		for _, key := range sortedFieldKeys(next) {
+			if old, ok := current[key]; ok && jsonEqual(old, next[key]) {
+				continue
+			}
+			if data, err = jsonedit.Set(data, next[key], childPath(entryPath, key)...); err != nil {
+				return nil, err
+			}
+		}
+		return data, nil
+	})
+}
+
+func (w *mcpWriterImpl) Remove(server domain.MCPServer) error {
+	return w.edit(server.Scope, func(data []byte, serversPath []string) ([]byte, error) {
+		entryPath := childPath(serversPath, server.Name)
+		if _, err := w.currentEntry(data, entryPath, server); err != nil {
+			return nil, err
+		}
+		return jsonedit.Delete(data, entryPath...)
+	})
+}
+
+// Copy writes target, a possibly renamed or rewritten version of server in
+// another scope. An existing entry with the target's name is only replaced
+// when overwrite is set.
+func (w *mcpWriterImpl) Copy(server domain.MCPServer, target domain.MCPServer, overwrite bool) error {
+	if server.Scope == target.Scope && server.Name == target.Name {
+		return errors.New("source and target are the same server")
+	}
+
+	return w.Put(overwrite, target)
+}
+
+// Move copies server to target and then removes the original. If the
+// original can't be removed the copy is rolled back, restoring the entry it
+// overwrote.
+func (w *mcpWriterImpl) Move(server domain.MCPServer, target domain.MCPServer, overwrite bool) error {
+	// Check the original first so a stale move never touches the target
+	if err := w.verify(server); err != nil {
+		return err
+	}
+	previous, err := w.entry(target.Scope, target.Name)
+	if err != nil {
+		return err
+	}
+	if err := w.Copy(server, target, overwrite); err != nil {
+		return err
+	}
+
+	if err := w.Remove(server); err != nil {
+		if rollbackErr := w.restore(target, previous); rollbackErr != nil {
+			w.logger.Error("failed to roll back MCP server copy", "name", target.Name, "scope", target.Scope, "error", rollbackErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// Rename changes the key of server's entry in place, keeping its position
+// and fields
+func (w *mcpWriterImpl) Rename(server domain.MCPServer, name string) error {
+	return w.edit(server.Scope, func(data []byte, serversPath []string) ([]byte, error) {
+		entryPath := childPath(serversPath, server.Name)
+		if _, err := w.currentEntry(data, entryPath, server); err != nil {
+			return nil, err
+		}
+		if _, err := jsonedit.Find(data, childPath(serversPath, name)...); err == nil {
+			return nil, fmt.Errorf("%w: %s (%s)", ErrServerExists, name, server.Scope)
+		}
+		return jsonedit.RenameKey(data, name, entryPath...)
+	})
+}
+
+// entry returns the raw entry named name in the scope's config, or nil if
+// there is none
+func (w *mcpWriterImpl) entry(scope domain.CapabilityScope, name string) (json.RawMessage, error) {
+	configPath, serversPath, err := loaders.MCPConfigLocation(scope)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := utils.ReadFileSnapshot(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(snapshot.Data)) == 0 {
+		return nil, nil
+	}
+	raw, err := jsonedit.Find(snapshot.Data, childPath(serversPath, name)...)
+	if errors.Is(err, jsonedit.ErrNotFound) {
+		return nil, nil
+	}
+	return raw, err
+}
+
+// restore undoes a copy to target: the entry it replaced is written back,
+// or the copy is removed if there was none
+func (w *mcpWriterImpl) restore(target domain.MCPServer, previous json.RawMessage) error {
+	if previous == nil {
+		return w.Remove(target)
+	}
+	return w.edit(target.Scope, func(data []byte, serversPath []string) ([]byte, error) {
+		return jsonedit.Set(data, previous, childPath(serversPath, target.Name)...)
+	})
+}
+
+// verify checks that server still matches its entry on disk
+func (w *mcpWriterImpl) verify(server domain.MCPServer) error {
+	configPath, serversPath, err := loaders.MCPConfigLocation(server.Scope)
+	if err != nil {
+		return err
+	}
+	snapshot, err := utils.ReadFileSnapshot(configPath)
+	if err != nil {
+		return err
+	}
+	_, err = w.currentEntry(snapshot.Data, childPath(serversPath, server.Name), server)
+	return err
+}
+
+// edit applies fn to the scope's config file and writes the result back
+// atomically, refusing to overwrite changes made in the meantime
+func (w *mcpWriterImpl) edit(scope domain.CapabilityScope, fn func(data []byte, serversPath []string) ([]byte, error)) error {
+	configPath, serversPath, err := loaders.MCPConfigLocation(scope)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := utils.ReadFileSnapshot(configPath)
+	if err != nil {
+		w.logger.Error("failed to read config file", "path", configPath, "error", err)
+		return err
+	}
+
+	data, err := fn(snapshot.Data, serversPath)
+	if err != nil {
+		return err
+	}
+	if !bytes.HasSuffix(data, []byte("\n")) {
+		data = append(data, '\n')
+	}
+
+	if err := utils.WriteFileAtomic(snapshot, data); err != nil {
+		w.logger.Error("failed to write config file", "path", snapshot.Path, "error", err)
+		return err
+	}
+
+	w.logger.Info("updated MCP config", "path", snapshot.Path, "scope", scope)
+	return nil
+}
+
+// currentEntry returns the fields of the entry on disk after checking it
+// still matches what the caller loaded
+func (w *mcpWriterImpl) currentEntry(data []byte, entryPath []string, loaded domain.MCPServer) (map[string]json.RawMessage, error) {
+	raw, err := jsonedit.Find(data, entryPath...)
+	if errors.Is(err, jsonedit.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s (%s)", ErrServerNotFound, loaded.Name, loaded.Scope)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var onDisk loaders.MCPServerConfig
+	if err := json.Unmarshal(raw, &onDisk); err != nil {
+		return nil, err
+	}
+
+	current, err := configFields(onDisk)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := configFields(loaders.NewMCPServerConfig(&loaded))
+	if err != nil {
+		return nil, err
+	}
+	if len(current) != len(expected) {
+		return nil, fmt.Errorf("%w: %s", ErrServerChanged, loaded.Name)
+	}
+	for key, value := range expected {
+		if !jsonEqual(current[key], value) {
+			return nil, fmt.Errorf("%w: %s", ErrServerChanged, loaded.Name)
+		}
+	}
+	return current, nil
+}
+
+// configFields splits a config entry into its top-level fields
+func configFields(config loaders.MCPServerConfig) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// sortedFieldKeys returns the keys of an entry in the order claudectl
+// writes them, so new fields land in a predictable place
+func sortedFieldKeys(fields map[string]json.RawMessage) []string {
+	order := []string{"type", "command", "args", "env", "url", "headers", "headersHelper", "timeout"}
+	keys := make([]string, 0, len(fields))
+	seen := map[string]bool{}
+	for _, key := range order {
+		if _, ok := fields[key]; ok {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+	var rest []string
+	for key := range fields {
+		if !seen[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
+
+func jsonEqual(a, b json.RawMessage) bool {
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return bytes.Equal(a, b)
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
+
+func childPath(path []string, key string) []string {
+	return append(append([]string(nil), path...), key)
+}